@@ -4,6 +4,11 @@ namespace Tips.Api.DTOs.Leaderboard;
 
 internal sealed class SubmitScoreRequestValidator : AbstractValidator<SubmitScoreRequest>
 {
+    private static readonly string[] GameModes = ["normal", "crazy", "ai"];
+
+    // Allow for clients whose clocks run slightly fast.
+    private static readonly TimeSpan MaxClockSkew = TimeSpan.FromMinutes(5);
+
     public SubmitScoreRequestValidator()
     {
         RuleFor(x => x.Score)
@@ -20,5 +25,16 @@ internal sealed class SubmitScoreRequestValidator : AbstractValidator<SubmitScor
 
         RuleFor(x => x.SnakeLength)
             .GreaterThanOrEqualTo(1).WithMessage("Snake length must be at least 1.");
+
+        RuleFor(x => x.Mode)
+            .Must(mode => mode is not null &&
+                GameModes.Contains(mode.Trim(), StringComparer.OrdinalIgnoreCase))
+            .When(x => x.Mode is not null)
+            .WithMessage("Mode must be one of: normal, crazy, ai.");
+
+        RuleFor(x => x.PlayedAt)
+            .Must(playedAt => playedAt <= DateTimeOffset.UtcNow + MaxClockSkew)
+            .When(x => x.PlayedAt is not null)
+            .WithMessage("PlayedAt cannot be in the future.");
     }
 }
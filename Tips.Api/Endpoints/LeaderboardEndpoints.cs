@@ -14,6 +14,8 @@ internal static class LeaderboardEndpoints
 {
     private static readonly TimeSpan SseRetryInterval = TimeSpan.FromSeconds(5);
 
+    private const string DefaultGameMode = "normal";
+
     public static void MapLeaderboardEndpoints(this WebApplication _, IEndpointRouteBuilder routes)
     {
         RouteGroupBuilder group = routes.MapGroup("leaderboard")
@@ -71,7 +73,8 @@ internal static class LeaderboardEndpoints
     private static async Task<Results<Created<LeaderboardEntry>, ValidationProblem>> SubmitScore(
         SubmitScoreRequest request,
         ILeaderboardRepository repo,
-        IValidator<SubmitScoreRequest> validator)
+        IValidator<SubmitScoreRequest> validator,
+        [FromHeader(Name = "Idempotency-Key")] string? idempotencyKey)
     {
         await validator.ValidateAndThrowAsync(request);
 
@@ -79,7 +82,10 @@ internal static class LeaderboardEndpoints
             request.PlayerName.Trim(),
             request.Score,
             request.Level,
-            request.SnakeLength);
+            request.SnakeLength,
+            request.Mode?.Trim() ?? DefaultGameMode,
+            request.PlayedAt ?? DateTimeOffset.UtcNow,
+            idempotencyKey);
 
         return TypedResults.Created($"/leaderboard/{entry.EntryId}", entry);
     }
@@ -132,9 +138,9 @@ internal static class LeaderboardEndpoints
             // Stream live events until the client disconnects.
             try
             {
-                await foreach (var change in reader.ReadAllAsync(cancellationToken))
+                await foreach (var item in reader.ReadAllAsync(cancellationToken))
                 {
-                    yield return eventBuffer.Add(change);
+                    yield return item;
                 }
             }
             finally
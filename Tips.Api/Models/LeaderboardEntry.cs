@@ -6,4 +6,5 @@ public sealed record LeaderboardEntry(
     int Score, 
     int Level, 
     int SnakeLength, 
+    string Mode,
     DateTimeOffset PlayedAt);
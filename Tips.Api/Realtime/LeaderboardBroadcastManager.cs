@@ -1,5 +1,6 @@
 ﻿using System.Collections.Concurrent;
 using System.Diagnostics.Metrics;
+using System.Net.ServerSentEvents;
 using System.Threading.Channels;
 using Tips.Api.Models;
 
@@ -9,7 +10,7 @@ internal sealed class LeaderboardBroadcastManager : IDisposable
 {
     private const int ChannelCapacity = 50;
 
-    private readonly ConcurrentDictionary<string, Channel<LeaderboardChangeEvent>> _subscribers = new();
+    private readonly ConcurrentDictionary<string, Channel<SseItem<LeaderboardChangeEvent>>> _subscribers = new();
     private readonly ILogger<LeaderboardBroadcastManager> _logger;
     private readonly Meter _meter;
     private readonly ObservableGauge<int> _subscriberGauge;
@@ -29,10 +30,10 @@ internal sealed class LeaderboardBroadcastManager : IDisposable
     /// <summary>
     /// Registers a new subscriber and returns a channel reader to stream from.
     /// </summary>
-    public (string connectionId, ChannelReader<LeaderboardChangeEvent> reader) Subscribe()
+    public (string connectionId, ChannelReader<SseItem<LeaderboardChangeEvent>> reader) Subscribe()
     {
         var connectionId = Guid.NewGuid().ToString("N");
-        var channel = Channel.CreateBounded<LeaderboardChangeEvent>(
+        var channel = Channel.CreateBounded<SseItem<LeaderboardChangeEvent>>(
             new BoundedChannelOptions(ChannelCapacity)
             {
                 FullMode = BoundedChannelFullMode.DropOldest,
@@ -69,16 +70,17 @@ internal sealed class LeaderboardBroadcastManager : IDisposable
     }
 
     /// <summary>
-    /// Writes <paramref name="change"/> to every subscriber's channel.
+    /// Writes <paramref name="item"/> to every subscriber's channel. The item must
+    /// already carry its event ID so every client sees the same ID for it.
     /// Never blocks — slow clients silently drop their oldest buffered event.
     /// Returns the number of channels that accepted the write.
     /// </summary>
-    public int Broadcast(LeaderboardChangeEvent change)
+    public int Broadcast(SseItem<LeaderboardChangeEvent> item)
     {
         int delivered = 0;
         foreach (var (id, ch) in _subscribers)
         {
-            if (ch.Writer.TryWrite(change))
+            if (ch.Writer.TryWrite(item))
             {
                 delivered++;
             }
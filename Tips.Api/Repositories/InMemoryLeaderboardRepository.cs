@@ -11,7 +11,46 @@ internal sealed class InMemoryLeaderboardRepository(
 {
     private readonly ConcurrentDictionary<string, LeaderboardEntry> _entries = new();
 
-    public LeaderboardEntry Add(string playerName, int score, int level, int snakeLength)
+    // Maps client idempotency keys to the entry they created, so a retried
+    // submission returns the original entry instead of adding a duplicate.
+    private readonly Dictionary<string, string> _idempotencyKeys = [];
+    private readonly Lock _idempotencyLock = new();
+
+    public LeaderboardEntry Add(
+        string playerName,
+        int score,
+        int level,
+        int snakeLength,
+        string mode,
+        DateTimeOffset playedAt,
+        string? idempotencyKey = null)
+    {
+        if (string.IsNullOrWhiteSpace(idempotencyKey))
+        {
+            return AddEntry(playerName, score, level, snakeLength, mode, playedAt);
+        }
+
+        lock (_idempotencyLock)
+        {
+            if (_idempotencyKeys.TryGetValue(idempotencyKey, out var existingId) &&
+                _entries.TryGetValue(existingId, out var existing))
+            {
+                return existing;
+            }
+
+            var entry = AddEntry(playerName, score, level, snakeLength, mode, playedAt);
+            _idempotencyKeys[idempotencyKey] = entry.EntryId;
+            return entry;
+        }
+    }
+
+    private LeaderboardEntry AddEntry(
+        string playerName,
+        int score,
+        int level,
+        int snakeLength,
+        string mode,
+        DateTimeOffset playedAt)
     {
         var entry = new LeaderboardEntry(
             EntryId: Guid.NewGuid().ToString("N"),
@@ -19,7 +58,8 @@ internal sealed class InMemoryLeaderboardRepository(
             Score: score,
             Level: level,
             SnakeLength: snakeLength,
-            PlayedAt: DateTimeOffset.UtcNow
+            Mode: mode,
+            PlayedAt: playedAt
         );
 
         _entries[entry.EntryId] = entry;
@@ -63,13 +103,9 @@ internal sealed class InMemoryLeaderboardRepository(
 
     private void Publish(LeaderboardChangeType changeType, LeaderboardEntry entry)
     {
-        if (broadcastManager.SubscriberCount == 0)
-        {
-            return;
-        }
-
-        var change = new LeaderboardChangeEvent(changeType, entry);
-        eventBuffer.Add(change);
-        broadcastManager.Broadcast(change);
+        // Buffer even without subscribers so a client that is reconnecting
+        // can replay what it missed via Last-Event-ID.
+        var item = eventBuffer.Add(new LeaderboardChangeEvent(changeType, entry));
+        broadcastManager.Broadcast(item);
     }
 }
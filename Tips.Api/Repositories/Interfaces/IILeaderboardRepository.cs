@@ -4,8 +4,18 @@ namespace Tips.Api.Repositories.Interfaces;
 
 public interface ILeaderboardRepository
 {
-    /// <summary>Adds a new entry and returns it with its assigned ID.</summary>
-    LeaderboardEntry Add(string playerName, int score, int level, int snakeLength);
+    /// <summary>
+    /// Adds a new entry and returns it with its assigned ID. If an entry was already
+    /// added with the same <paramref name="idempotencyKey"/>, that entry is returned instead.
+    /// </summary>
+    LeaderboardEntry Add(
+        string playerName,
+        int score,
+        int level,
+        int snakeLength,
+        string mode,
+        DateTimeOffset playedAt,
+        string? idempotencyKey = null);
 
     /// <summary>Returns all entries ordered by score descending.</summary>
     IReadOnlyList<LeaderboardEntry> GetAll();
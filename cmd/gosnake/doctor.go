@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HilthonTT/gosnake/internal/config"
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/internal/services/leaderboard"
+	"github.com/HilthonTT/gosnake/internal/services/remote"
+)
+
+type DoctorCmd struct{}
+
+// doctor accumulates check results so every check runs even if one fails.
+type doctor struct {
+	failed int
+}
+
+func (d *doctor) pass(format string, args ...any) {
+	fmt.Printf("  ✓ "+format+"\n", args...)
+}
+
+func (d *doctor) fail(format string, args ...any) {
+	d.failed++
+	fmt.Printf("  ✗ "+format+"\n", args...)
+}
+
+func (d *doctor) info(format string, args ...any) {
+	fmt.Printf("  - "+format+"\n", args...)
+}
+
+func (c *DoctorCmd) Run(globals *GlobalVars) error {
+	d := &doctor{}
+
+	fmt.Println("Local")
+	cfg, err := config.GetConfig(globals.Config)
+	if err != nil {
+		d.fail("config %s: %v", globals.Config, err)
+		return fmt.Errorf("%d check(s) failed", d.failed)
+	}
+	d.pass("config %s", globals.Config)
+
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		d.fail("database %s: %v", globals.DB, err)
+	} else {
+		defer db.Close()
+		n, err := data.NewLeaderboardRepository(db).Count(data.LeaderboardFilter{})
+		if err != nil {
+			d.fail("database %s: %v", globals.DB, err)
+		} else {
+			d.pass("database %s (%d leaderboard entries)", globals.DB, n)
+		}
+	}
+
+	fmt.Println("Remote leaderboard")
+	r := cfg.Remote
+	if !r.Enabled {
+		d.info("disabled (set remote.enabled = true or GOSNAKE_REMOTE_ENABLED=1)")
+		return d.result()
+	}
+	d.info("base url %s, timeout %s, %d retries", r.BaseURL, r.Timeout, r.Retries)
+	if r.InsecureSkipVerify {
+		d.info("TLS verification is DISABLED")
+	}
+	if len(r.PinnedSHA256) > 0 {
+		d.info("%d pinned key(s)", len(r.PinnedSHA256))
+	}
+
+	client, err := remote.NewHTTPClient(r)
+	if err != nil {
+		d.fail("tls setup: %v", err)
+		return d.result()
+	}
+
+	ctx := context.Background()
+	if err := remote.CheckHealth(ctx, client, r.BaseURL); err != nil {
+		d.fail("health check: %v", err)
+		return d.result()
+	}
+	d.pass("health check")
+
+	svc, err := leaderboard.NewLeaderboardService(r)
+	if err != nil {
+		d.fail("leaderboard client: %v", err)
+		return d.result()
+	}
+	if _, err := svc.GetLeaderboard(ctx, 1); err != nil {
+		d.fail("leaderboard query: %v", err)
+	} else {
+		d.pass("leaderboard query")
+	}
+
+	return d.result()
+}
+
+func (d *doctor) result() error {
+	if d.failed > 0 {
+		return fmt.Errorf("%d check(s) failed", d.failed)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/config"
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/internal/services/leaderboard"
+	"github.com/HilthonTT/gosnake/internal/tui"
+)
+
+type LeaderboardCmd struct {
+	Show   LeaderboardShowCmd   `cmd:"" help:"Open the leaderboard, or print it when any query flag is given" default:"withargs"`
+	Export LeaderboardExportCmd `cmd:"" help:"Write leaderboard entries to stdout as CSV or JSON"`
+	Import LeaderboardImportCmd `cmd:"" help:"Merge leaderboard entries from a CSV or JSON file"`
+	Delete LeaderboardDeleteCmd `cmd:"" help:"Delete a leaderboard entry by id"`
+	Sync   LeaderboardSyncCmd   `cmd:"" help:"Pull remote scores and push local ones to the remote leaderboard"`
+}
+
+type LeaderboardShowCmd struct {
+	Top    int    `help:"Only print the N highest scores" default:"0"`
+	Mode   string `help:"Only print entries for this game mode (normal, crazy, ai)" default:""`
+	Player string `help:"Only print entries for this player name" default:""`
+	Since  string `help:"Only print entries newer than a duration (7d, 12h) or date (2006-01-02)" default:""`
+	Source string `help:"Only print local, global (remote) or merged entries" enum:"local,global,merged," default:""`
+	JSON   bool   `help:"Print JSON instead of a table" name:"json"`
+	Table  bool   `help:"Print a table instead of opening the UI"`
+}
+
+func (c *LeaderboardShowCmd) Run(globals *GlobalVars) error {
+	if !c.headless() {
+		return launchStarter(globals, tui.ModeLeaderboard, tui.NewLeaderboardInput())
+	}
+
+	filter := data.LeaderboardFilter{Name: c.Player, Limit: c.Top}
+	if c.Mode != "" {
+		mode, err := data.ParseGameMode(c.Mode)
+		if err != nil {
+			return err
+		}
+		filter.Mode = mode
+	}
+	if c.Since != "" {
+		since, err := parseSince(c.Since, time.Now())
+		if err != nil {
+			return err
+		}
+		filter.Since = since
+	}
+	if c.Source != "" {
+		scope, err := data.ParseLeaderboardScope(c.Source)
+		if err != nil {
+			return err
+		}
+		filter.Scope = scope
+	}
+
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	entries, err := data.NewLeaderboardRepository(db).Find(filter)
+	if err != nil {
+		return fmt.Errorf("fetching leaderboard entries: %w", err)
+	}
+
+	if c.JSON {
+		if entries == nil {
+			entries = []data.LeaderboardEntry{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+	return printLeaderboardTable(os.Stdout, entries)
+}
+
+// headless reports whether any flag asking for plain output was given.
+func (c *LeaderboardShowCmd) headless() bool {
+	return c.Top > 0 || c.Mode != "" || c.Player != "" || c.Since != "" || c.Source != "" || c.JSON || c.Table
+}
+
+func printLeaderboardTable(w io.Writer, entries []data.LeaderboardEntry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tID\tNAME\tSCORE\tLEVEL\tMODE\tDATE")
+	for i, e := range entries {
+		date := e.CreatedAt
+		if len(date) >= 10 {
+			date = date[:10]
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%d\t%s\t%s\n", i+1, e.ID, e.Name, e.Score, e.Level, e.Mode, date)
+	}
+	return tw.Flush()
+}
+
+// parseSince turns "7d", "12h", "30m" or "2006-01-02" into an absolute time.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid --since value '%s'", s)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value '%s'", s)
+}
+
+type LeaderboardExportCmd struct {
+	Format string `help:"Output format (csv, json)" enum:"csv,json" default:"csv" short:"f"`
+	Mode   string `help:"Only export entries for this game mode (normal, crazy, ai)" default:""`
+	Player string `help:"Only export entries for this player name" default:""`
+}
+
+func (c *LeaderboardExportCmd) Run(globals *GlobalVars) error {
+	filter := data.LeaderboardFilter{Name: c.Player}
+	if c.Mode != "" {
+		mode, err := data.ParseGameMode(c.Mode)
+		if err != nil {
+			return err
+		}
+		filter.Mode = mode
+	}
+
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	entries, err := data.NewLeaderboardRepository(db).Find(filter)
+	if err != nil {
+		return fmt.Errorf("fetching leaderboard entries: %w", err)
+	}
+
+	return data.WriteEntries(os.Stdout, data.TransferFormat(c.Format), entries)
+}
+
+type LeaderboardImportCmd struct {
+	File   string `arg:"" help:"CSV or JSON file to import" type:"existingfile"`
+	Format string `help:"Input format (csv, json). Inferred from the file extension when empty." enum:"csv,json," default:"" short:"f"`
+}
+
+func (c *LeaderboardImportCmd) Run(globals *GlobalVars) error {
+	format := data.TransferFormat(c.Format)
+	if format == "" {
+		var err error
+		format, err = data.FormatFromPath(c.File)
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(c.File)
+	if err != nil {
+		return fmt.Errorf("opening import file: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := data.ReadEntries(f, format)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", c.File, err)
+	}
+
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	res, err := data.NewLeaderboardRepository(db).Import(entries)
+	if err != nil {
+		return fmt.Errorf("importing leaderboard entries: %w", err)
+	}
+
+	fmt.Printf("Imported %d entries (%d duplicates skipped)\n", res.Inserted, res.Skipped)
+	return nil
+}
+
+type LeaderboardDeleteCmd struct {
+	ID int `arg:"" help:"Id of the entry to delete (see 'gosnake leaderboard --table')"`
+}
+
+func (c *LeaderboardDeleteCmd) Run(globals *GlobalVars) error {
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	if err := data.NewLeaderboardRepository(db).Delete(c.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted entry %d\n", c.ID)
+	return nil
+}
+
+type LeaderboardSyncCmd struct {
+	Timeout time.Duration `help:"Give up after this long" default:"1m"`
+}
+
+func (c *LeaderboardSyncCmd) Run(globals *GlobalVars) error {
+	cfg, err := config.GetConfig(globals.Config)
+	if err != nil {
+		return fmt.Errorf("getting config: %w", err)
+	}
+	if !cfg.Remote.Enabled {
+		return errors.New("remote leaderboard is disabled (set remote.enabled = true or GOSNAKE_REMOTE_ENABLED=1)")
+	}
+
+	service, err := leaderboard.NewLeaderboardService(cfg.Remote)
+	if err != nil {
+		return err
+	}
+
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	outbox := leaderboard.NewOutbox(data.NewOutboxRepository(db), service)
+	syncer := leaderboard.NewSyncer(data.NewLeaderboardRepository(db), outbox, service)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	res, err := syncer.Sync(ctx)
+	if err != nil {
+		return fmt.Errorf("syncing leaderboard: %w", err)
+	}
+
+	fmt.Printf("Pulled %d, pushed %d, %d pending\n", res.Pulled, res.Pushed, res.Pending)
+	return nil
+}
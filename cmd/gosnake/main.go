@@ -16,11 +16,13 @@ type CLI struct {
 	Play        PlayCmd        `cmd:"" help:"Start in the game"`
 	Leaderboard LeaderboardCmd `cmd:"" help:"Start on the leaderboard"`
 	Serve       ServeCmd       `cmd:"" help:"Start a multiplayer SSH server"`
+	Doctor      DoctorCmd      `cmd:"" help:"Check the local setup and remote leaderboard connectivity"`
+	Verify      VerifyCmd      `cmd:"" help:"Check a replay file's signature and replay it to confirm its score"`
 }
 
 type GlobalVars struct {
-	Config   string     `help:"Path to config file. Empty value will use XDG data directory." default:""`
-	DB       string     `help:"Path to database file. Empty value will use XDG data directory." default:""`
+	Config   string     `help:"Path to config file (the server's own config file for serve). Empty value will use XDG data directory." default:""`
+	DB       string     `help:"Path to database file (the server's own database for serve). Empty value will use XDG data directory." default:""`
 	LogLevel slog.Level `help:"Log level (DEBUG, INFO, WARN, ERROR)" default:"INFO" env:"GOSNAKE_LOG_LEVEL"`
 	LogFile  string     `help:"Path to log file." default:"gosnake.log" env:"GOSNAKE_LOG_FILE"`
 }
@@ -33,7 +35,7 @@ func main() {
 		kong.UsageOnError(),
 	)
 
-	if err := handleDefaultGlobals(&cli.GlobalVars); err != nil {
+	if err := handleDefaultGlobals(&cli.GlobalVars, ctx.Command()); err != nil {
 		ctx.FatalIfErrorf(err)
 	}
 
@@ -48,17 +50,28 @@ func main() {
 	ctx.FatalIfErrorf(err)
 }
 
-func handleDefaultGlobals(g *GlobalVars) error {
+func handleDefaultGlobals(g *GlobalVars, command string) error {
 	if g.Config == "" {
+		name := "./gosnake/config.toml"
+		if command == "serve" {
+			name = "./gosnake/server.toml"
+		}
+
 		var err error
-		g.Config, err = xdg.ConfigFile("./gosnake/config.toml")
+		g.Config, err = xdg.ConfigFile(name)
 		if err != nil {
 			return err
 		}
 	}
 	if g.DB == "" {
+		// The SSH server keeps multiplayer results apart from local scores.
+		name := "./gosnake/tetrigo.db"
+		if command == "serve" {
+			name = "./gosnake/server.db"
+		}
+
 		var err error
-		g.DB, err = xdg.DataFile("./gosnake/tetrigo.db")
+		g.DB, err = xdg.DataFile(name)
 		if err != nil {
 			return err
 		}
@@ -78,7 +91,7 @@ func setupLogger(logPath string, level slog.Level) (*os.File, error) {
 	if os.Getenv("ENV") == "production" {
 		handler = slog.NewJSONHandler(f, opts)
 	} else {
-		handler = slog.NewTextHandler(f, &slog.HandlerOptions{})
+		handler = slog.NewTextHandler(f, opts)
 	}
 
 	slog.SetDefault(slog.New(handler))
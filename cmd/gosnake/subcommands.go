@@ -46,12 +46,6 @@ func (c *PlayCmd) Run(globals *GlobalVars) error {
 	return launchStarter(globals, mode, tui.NewSingleInput(mode, c.Level, c.Name))
 }
 
-type LeaderboardCmd struct{}
-
-func (c *LeaderboardCmd) Run(globals *GlobalVars) error {
-	return launchStarter(globals, tui.ModeLeaderboard, tui.NewLeaderboardInput())
-}
-
 func launchStarter(globals *GlobalVars, starterMode tui.Mode, switchIn tui.SwitchModeInput) (retErr error) {
 
 	db, err := data.NewDB(globals.DB)
@@ -113,19 +107,49 @@ func launchStarter(globals *GlobalVars, starterMode tui.Mode, switchIn tui.Switc
 	return nil
 }
 
+// ServeCmd flags override the server config file; unset flags fall back to it.
 type ServeCmd struct {
-	Key  string `help:"Path to SSH host key file" default:"gosnake_server" env:"GOSNAKE_KEY"`
-	Host string `help:"Host address to bind to (empty = all interfaces)" default:"" env:"GOSNAKE_HOST"`
-	Port int    `help:"TCP port to listen on" default:"2222" env:"GOSNAKE_PORT"`
+	Key  string `help:"Path to SSH host key file (default gosnake_server)" env:"GOSNAKE_KEY"`
+	Host string `help:"Host address to bind to (empty = all interfaces)" env:"GOSNAKE_HOST"`
+	Port int    `help:"TCP port to listen on (default 2222)" env:"GOSNAKE_PORT"`
 }
 
-func (c *ServeCmd) Run(_ *GlobalVars) error {
-	srv, err := server.NewServer(c.Key, c.Host, c.Port)
+func (c *ServeCmd) Run(globals *GlobalVars) error {
+	cfg, err := config.GetServerConfig(globals.Config)
+	if err != nil {
+		return fmt.Errorf("loading server config: %w", err)
+	}
+	if c.Key == "" {
+		c.Key = cfg.HostKey
+	}
+	if c.Host == "" {
+		c.Host = cfg.Host
+	}
+	if c.Port == 0 {
+		c.Port = cfg.Port
+	}
+
+	access, err := server.NewAccess(cfg.AuthorizedKeys, cfg.Admins, cfg.BannedKeys, cfg.BannedIPs)
+	if err != nil {
+		return fmt.Errorf("loading access rules: %w", err)
+	}
+
+	db, err := data.NewDB(globals.DB)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	srv, err := server.NewServer(c.Key, c.Host, c.Port, db, access)
 	if err != nil {
 		return fmt.Errorf("creating server: %w", err)
 	}
 
 	log.Printf("GoSnake multiplayer server starting on %s:%d", c.Host, c.Port)
+	log.Printf("Match results and ratings are stored in %s", globals.DB)
+	if access.Restricted() {
+		log.Printf("Only keys in %s may connect", cfg.AuthorizedKeys)
+	}
 	log.Printf("Players connect with: ssh <name>@<host> -p %d -t <room-id>", c.Port)
 
 	// Start serving in the background.
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+	"github.com/HilthonTT/gosnake/pkg/verify"
+)
+
+type VerifyCmd struct {
+	File string `arg:"" help:"Replay file to verify (written after every game)" type:"existingfile"`
+	JSON bool   `help:"Print the result as JSON" name:"json"`
+}
+
+// verifyReport is the --json output, meant for scripts and servers that
+// shell out to check submitted runs.
+type verifyReport struct {
+	Valid       bool          `json:"valid"`
+	Error       string        `json:"error,omitempty"`
+	Fingerprint string        `json:"fingerprint"`
+	Claim       verify.Claim  `json:"claim"`
+	Simulated   replay.Result `json:"simulated"`
+}
+
+func (c *VerifyCmd) Run(_ *GlobalVars) error {
+	f, err := os.Open(c.File)
+	if err != nil {
+		return fmt.Errorf("opening replay: %w", err)
+	}
+	defer f.Close()
+
+	run, err := verify.ReadRun(f)
+	if err != nil {
+		return fmt.Errorf("reading replay: %w", err)
+	}
+
+	res, verifyErr := verify.Verify(run)
+
+	if c.JSON {
+		report := verifyReport{
+			Valid:       verifyErr == nil,
+			Fingerprint: run.Proof.Fingerprint(),
+			Claim:       run.Proof.Claim,
+			Simulated:   res,
+		}
+		if verifyErr != nil {
+			report.Error = verifyErr.Error()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+	} else {
+		claim := run.Proof.Claim
+		fmt.Printf("Player:  %s (%s)\n", claim.PlayerName, run.Proof.Fingerprint())
+		fmt.Printf("Claim:   %s mode, score %d, level %d, length %d, %d ticks\n",
+			claim.Mode, claim.Score, claim.Level, claim.SnakeLength, claim.Ticks)
+		if verifyErr == nil {
+			fmt.Println("Result:  valid")
+		}
+	}
+
+	if verifyErr != nil {
+		return fmt.Errorf("replay is not valid: %w", verifyErr)
+	}
+	return nil
+}
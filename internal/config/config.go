@@ -11,20 +11,28 @@ import (
 type Config struct {
 	// The keybindings for the game
 	Keys *Keys `toml:"keys"`
+
+	// The online leaderboard service
+	Remote *Remote `toml:"remote"`
 }
 
 func GetConfig(path string) (*Config, error) {
 	c := &Config{
-		Keys: DefaultKeys(),
+		Keys:   DefaultKeys(),
+		Remote: DefaultRemote(),
 	}
 
 	_, err := toml.DecodeFile(path, c)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return c, nil
-		}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return nil, fmt.Errorf("decoding toml file: %w", err)
 	}
 
+	if err := c.Remote.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := c.Remote.validate(); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
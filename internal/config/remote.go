@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRemoteBaseURL = "https://localhost:5001"
+	DefaultRemoteTimeout = 10 * time.Second
+
+	DefaultRemoteRetries          = 2
+	DefaultRemoteBreakerThreshold = 3
+	DefaultRemoteBreakerCooldown  = 30 * time.Second
+)
+
+// Remote configures the optional online leaderboard service (Tips.Api).
+// Every field can be overridden by a GOSNAKE_REMOTE_* environment variable.
+type Remote struct {
+	// Enabled turns all remote traffic on. Off by default so nothing is sent
+	// unless a server has actually been configured.
+	Enabled bool `toml:"enabled"`
+
+	// BaseURL is the scheme and host of the API, without the /api/v1 suffix.
+	BaseURL string `toml:"base_url"`
+
+	// CAFile is a PEM bundle trusted in addition to the system roots, for
+	// servers using a private or self-signed certificate.
+	CAFile string `toml:"ca_file"`
+
+	// PinnedSHA256 lists base64 SHA-256 hashes of accepted server public keys
+	// (SPKI). When set, the leaf certificate must match one of them.
+	PinnedSHA256 []string `toml:"pinned_sha256"`
+
+	// Timeout bounds each request, e.g. "10s".
+	Timeout time.Duration `toml:"timeout"`
+
+	// InsecureSkipVerify disables certificate verification. Only meant for
+	// local development against the bundled dev certificate.
+	InsecureSkipVerify bool `toml:"insecure_skip_verify"`
+
+	// Retries is how many times a request that failed with a network error
+	// or a 5xx status is retried. Zero disables retries.
+	Retries int `toml:"retries"`
+
+	// BreakerThreshold is the number of consecutive failures after which
+	// requests fail fast for BreakerCooldown instead of waiting on a dead
+	// server. Zero disables the circuit breaker.
+	BreakerThreshold int           `toml:"breaker_threshold"`
+	BreakerCooldown  time.Duration `toml:"breaker_cooldown"`
+}
+
+func DefaultRemote() *Remote {
+	return &Remote{
+		Enabled: false,
+		BaseURL: DefaultRemoteBaseURL,
+		Timeout: DefaultRemoteTimeout,
+
+		Retries:          DefaultRemoteRetries,
+		BreakerThreshold: DefaultRemoteBreakerThreshold,
+		BreakerCooldown:  DefaultRemoteBreakerCooldown,
+	}
+}
+
+// applyEnv overrides fields from GOSNAKE_REMOTE_* environment variables.
+func (r *Remote) applyEnv() error {
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_ENABLED"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("parsing GOSNAKE_REMOTE_ENABLED: %w", err)
+		}
+		r.Enabled = b
+	}
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_URL"); ok {
+		r.BaseURL = v
+	}
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_CA_FILE"); ok {
+		r.CAFile = v
+	}
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_PINS"); ok {
+		r.PinnedSHA256 = nil
+		for _, pin := range strings.Split(v, ",") {
+			if pin = strings.TrimSpace(pin); pin != "" {
+				r.PinnedSHA256 = append(r.PinnedSHA256, pin)
+			}
+		}
+	}
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_TIMEOUT"); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("parsing GOSNAKE_REMOTE_TIMEOUT: %w", err)
+		}
+		r.Timeout = d
+	}
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_INSECURE"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("parsing GOSNAKE_REMOTE_INSECURE: %w", err)
+		}
+		r.InsecureSkipVerify = b
+	}
+	if v, ok := os.LookupEnv("GOSNAKE_REMOTE_RETRIES"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("parsing GOSNAKE_REMOTE_RETRIES: %w", err)
+		}
+		r.Retries = n
+	}
+	return nil
+}
+
+func (r *Remote) validate() error {
+	if r.BaseURL == "" {
+		return fmt.Errorf("remote base_url cannot be empty")
+	}
+	if r.Timeout <= 0 {
+		return fmt.Errorf("invalid remote timeout '%s'", r.Timeout)
+	}
+	if r.Retries < 0 {
+		return fmt.Errorf("invalid remote retries %d", r.Retries)
+	}
+	if r.BreakerThreshold < 0 {
+		return fmt.Errorf("invalid remote breaker_threshold %d", r.BreakerThreshold)
+	}
+	if r.BreakerThreshold > 0 && r.BreakerCooldown <= 0 {
+		return fmt.Errorf("invalid remote breaker_cooldown '%s'", r.BreakerCooldown)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	DefaultServerPort    = 2222
+	DefaultServerHostKey = "gosnake_server"
+)
+
+// Server configures the multiplayer SSH server started by `gosnake serve`.
+// Command-line flags and GOSNAKE_* environment variables take precedence.
+type Server struct {
+	// Host is the address to bind to; empty listens on all interfaces.
+	Host string `toml:"host"`
+	Port int    `toml:"port"`
+
+	// HostKey is the path of the server's SSH host key, created on first
+	// start.
+	HostKey string `toml:"host_key"`
+
+	// AuthorizedKeys is an OpenSSH authorized_keys file. When set, only the
+	// keys in it (and admin keys) may connect.
+	AuthorizedKeys string `toml:"authorized_keys"`
+
+	// Admins lists the SHA256 fingerprints of keys with the admin role,
+	// e.g. "SHA256:…" as printed by ssh-keygen -lf.
+	Admins []string `toml:"admins"`
+
+	// BannedKeys lists fingerprints of keys that may never connect.
+	BannedKeys []string `toml:"banned_keys"`
+
+	// BannedIPs lists addresses or CIDR ranges that may never connect.
+	BannedIPs []string `toml:"banned_ips"`
+}
+
+func DefaultServer() *Server {
+	return &Server{
+		Port:    DefaultServerPort,
+		HostKey: DefaultServerHostKey,
+	}
+}
+
+// GetServerConfig reads the server configuration at path. A missing file
+// yields the defaults.
+func GetServerConfig(path string) (*Server, error) {
+	c := DefaultServer()
+
+	_, err := toml.DecodeFile(path, c)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("decoding toml file: %w", err)
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Server) validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid server port %d", c.Port)
+	}
+	if c.HostKey == "" {
+		return fmt.Errorf("server host_key cannot be empty")
+	}
+	return nil
+}
@@ -2,13 +2,24 @@ package data
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 
 	// Import the sqlite driver.
 	_ "modernc.org/sqlite"
 )
 
+// busyTimeoutPragma makes concurrent writers (e.g. the background score sync
+// and the UI) wait for each other instead of failing with SQLITE_BUSY.
+const busyTimeoutPragma = "_pragma=busy_timeout(5000)"
+
 func NewDB(dataSourceName string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", dataSourceName)
+	sep := "?"
+	if strings.Contains(dataSourceName, "?") {
+		sep = "&"
+	}
+
+	db, err := sql.Open("sqlite", dataSourceName+sep+busyTimeoutPragma)
 	if err != nil {
 		return nil, err
 	}
@@ -21,7 +32,8 @@ func NewDB(dataSourceName string) (*sql.DB, error) {
 }
 
 func EnsureTableExists(db *sql.DB) error {
-	const query = `
+	queries := []string{
+		`
 		CREATE TABLE IF NOT EXISTS leaderboard (
 			id         INTEGER  PRIMARY KEY AUTOINCREMENT,
 			name       TEXT     NOT NULL,
@@ -30,8 +42,167 @@ func EnsureTableExists(db *sql.DB) error {
 			mode       TEXT     NOT NULL DEFAULT 'normal',
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		);
-	`
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS score_outbox (
+			id              INTEGER  PRIMARY KEY AUTOINCREMENT,
+			idempotency_key TEXT     NOT NULL UNIQUE,
+			name            TEXT     NOT NULL,
+			score           INTEGER  NOT NULL DEFAULT 0,
+			level           INTEGER  NOT NULL DEFAULT 1,
+			snake_length    INTEGER  NOT NULL DEFAULT 1,
+			mode            TEXT     NOT NULL DEFAULT 'normal',
+			attempts        INTEGER  NOT NULL DEFAULT 0,
+			next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			last_error      TEXT     NOT NULL DEFAULT '',
+			created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	// Columns added after the first release; older databases get them on
+	// their next start.
+	columns := []struct{ table, name, def string }{
+		{"leaderboard", "snake_length", "INTEGER NOT NULL DEFAULT 0"},
+		{"leaderboard", "origin", "TEXT NOT NULL DEFAULT 'local'"},
+		{"leaderboard", "remote_id", "TEXT"},
+		{"leaderboard", "proof", "TEXT"},
+		{"leaderboard", "replay", "TEXT"},
+		{"leaderboard", "rejected_at", "DATETIME"},
+		{"leaderboard", "undated", "INTEGER NOT NULL DEFAULT 0"},
+		{"score_outbox", "entry_id", "INTEGER"},
+		{"score_outbox", "played_at", "DATETIME"},
+	}
+	for _, c := range columns {
+		if err := addColumnIfMissing(db, c.table, c.name, c.def); err != nil {
+			return err
+		}
+	}
 
-	_, err := db.Exec(query)
+	_, err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS leaderboard_remote_id
+		ON leaderboard (remote_id) WHERE remote_id IS NOT NULL
+	`)
 	return err
 }
+
+// EnsureServerTables creates the tables only the multiplayer server uses:
+// match results, ratings and tournaments, keyed by SSH key fingerprint.
+// Players' local databases never get them.
+func EnsureServerTables(db *sql.DB) error {
+	queries := []string{
+		`
+		CREATE TABLE IF NOT EXISTS mp_players (
+			fingerprint TEXT     PRIMARY KEY,
+			name        TEXT     NOT NULL,
+			rating      REAL     NOT NULL DEFAULT 1500,
+			matches     INTEGER  NOT NULL DEFAULT 0,
+			wins        INTEGER  NOT NULL DEFAULT 0,
+			updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS mp_matches (
+			id         INTEGER  PRIMARY KEY AUTOINCREMENT,
+			room       TEXT     NOT NULL,
+			mode       TEXT     NOT NULL,
+			players    INTEGER  NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS mp_results (
+			match_id      INTEGER NOT NULL REFERENCES mp_matches (id),
+			fingerprint   TEXT    NOT NULL,
+			name          TEXT    NOT NULL,
+			score         INTEGER NOT NULL DEFAULT 0,
+			length        INTEGER NOT NULL DEFAULT 0,
+			place         INTEGER NOT NULL,
+			rating_before REAL    NOT NULL,
+			rating_after  REAL    NOT NULL
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS mp_results_fingerprint ON mp_results (fingerprint, match_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS mp_tournaments (
+			id         TEXT     PRIMARY KEY,
+			format     TEXT     NOT NULL,
+			status     TEXT     NOT NULL,
+			organiser  TEXT     NOT NULL,
+			champion   TEXT     NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS mp_tournament_players (
+			tournament_id TEXT    NOT NULL REFERENCES mp_tournaments (id),
+			seed          INTEGER NOT NULL,
+			name          TEXT    NOT NULL,
+			fingerprint   TEXT    NOT NULL DEFAULT '',
+			PRIMARY KEY (tournament_id, name)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS mp_tournament_matches (
+			tournament_id TEXT    NOT NULL REFERENCES mp_tournaments (id),
+			id            INTEGER NOT NULL,
+			bracket       TEXT    NOT NULL,
+			round         INTEGER NOT NULL,
+			player_a      TEXT    NOT NULL DEFAULT '',
+			player_b      TEXT    NOT NULL DEFAULT '',
+			winner        TEXT    NOT NULL DEFAULT '',
+			next_match    INTEGER NOT NULL DEFAULT 0,
+			next_slot     INTEGER NOT NULL DEFAULT 0,
+			loser_match   INTEGER NOT NULL DEFAULT 0,
+			loser_slot    INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (tournament_id, id)
+		);
+		`,
+	}
+
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addColumnIfMissing(db *sql.DB, table, column, def string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name, typ    string
+			notNull, pk  int
+			defaultValue sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultValue, &pk); err != nil {
+			return fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("column iteration error for %s: %w", table, err)
+	}
+	rows.Close()
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, def)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEnsureServerTables(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "gosnake.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tables := []string{"mp_players", "mp_matches", "mp_results", "mp_tournaments", "mp_tournament_players", "mp_tournament_matches"}
+	exists := func(table string) bool {
+		t.Helper()
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n == 1
+	}
+
+	for _, table := range tables {
+		if exists(table) {
+			t.Errorf("a local database has the server table %s", table)
+		}
+	}
+
+	// Twice, as every server start does.
+	for range 2 {
+		if err := EnsureServerTables(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, table := range tables {
+		if !exists(table) {
+			t.Errorf("server table %s was not created", table)
+		}
+	}
+}
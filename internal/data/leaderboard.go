@@ -2,25 +2,218 @@ package data
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+)
+
+// GameMode identifies which ruleset was played. It is snake.GameMode, which
+// replays and proofs carry too.
+type GameMode = snake.GameMode
+
+const (
+	GameModeNormal = snake.GameModeNormal
+	GameModeCrazy  = snake.GameModeCrazy
+	GameModeAI     = snake.GameModeAI
+)
+
+// ParseGameMode maps a case-insensitive mode name (e.g. "ai") to its GameMode.
+func ParseGameMode(s string) (GameMode, error) {
+	return snake.ParseGameMode(s)
+}
+
+const (
+	minLevel      = 1
+	maxLevel      = 10
+	maxNameLength = 100
+
+	// createdAtLayout matches what SQLite's CURRENT_TIMESTAMP produces.
+	createdAtLayout = "2006-01-02 15:04:05"
 )
 
-// GameMode identifies which ruleset was played.
-type GameMode string
+// EntryOrigin records where a leaderboard row was first created.
+type EntryOrigin string
 
 const (
-	GameModeNormal GameMode = "normal"
-	GameModeCrazy  GameMode = "crazy"
-	GameModeAI     GameMode = "AI"
+	OriginLocal  EntryOrigin = "local"  // played (or imported) on this machine
+	OriginRemote EntryOrigin = "remote" // pulled from the remote leaderboard
 )
 
 type LeaderboardEntry struct {
-	ID        int
-	Name      string
-	Score     int
-	Level     int
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Score       int         `json:"score"`
+	Level       int         `json:"level"`
+	Mode        GameMode    `json:"mode"`
+	CreatedAt   string      `json:"createdAt"`
+	SnakeLength int         `json:"snakeLength,omitempty"` // 0 when unknown
+	Origin      EntryOrigin `json:"origin,omitempty"`
+	RemoteID    string      `json:"remoteId,omitempty"` // entry ID on the remote leaderboard, once synced
+}
+
+// Validate reports whether the entry could have been produced by a real game.
+func (e LeaderboardEntry) Validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if len(e.Name) > maxNameLength {
+		return fmt.Errorf("name '%s' exceeds %d characters", e.Name, maxNameLength)
+	}
+	if e.Score < 0 {
+		return fmt.Errorf("invalid score '%d'", e.Score)
+	}
+	if e.Level < minLevel || e.Level > maxLevel {
+		return fmt.Errorf("invalid level '%d'", e.Level)
+	}
+	if _, err := ParseGameMode(string(e.Mode)); err != nil {
+		return err
+	}
+	if e.CreatedAt != "" {
+		if _, err := ParseTimestamp(e.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseTimestamp parses a stored timestamp such as LeaderboardEntry.CreatedAt.
+// It accepts both the SQLite timestamp layout and RFC 3339, which is what the
+// driver hands back when scanning a DATETIME column.
+func ParseTimestamp(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, createdAtLayout, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid created_at '%s'", s)
+}
+
+// LeaderboardOrder selects the column a Find query is sorted by.
+type LeaderboardOrder int
+
+const (
+	OrderByScore LeaderboardOrder = iota
+	OrderByLevel
+	OrderByName
+	OrderByMode
+	OrderByDate
+)
+
+var orderToColumnMap = map[LeaderboardOrder]string{
+	OrderByScore: "score",
+	OrderByLevel: "level",
+	OrderByName:  "name COLLATE NOCASE",
+	OrderByMode:  "mode",
+	OrderByDate:  "datetime(created_at)",
+}
+
+// LeaderboardScope selects which side of the remote sync a query covers.
+type LeaderboardScope int
+
+const (
+	ScopeMerged LeaderboardScope = iota // every row, local and remote
+	ScopeLocal                          // rows played on this machine
+	ScopeGlobal                         // rows known to the remote leaderboard
+)
+
+var scopeToStrMap = map[LeaderboardScope]string{
+	ScopeMerged: "Merged",
+	ScopeLocal:  "Local",
+	ScopeGlobal: "Global",
+}
+
+func (s LeaderboardScope) String() string {
+	return scopeToStrMap[s]
+}
+
+// ParseLeaderboardScope maps a case-insensitive scope name (e.g. "global") to
+// its LeaderboardScope.
+func ParseLeaderboardScope(s string) (LeaderboardScope, error) {
+	for scope, name := range scopeToStrMap {
+		if strings.EqualFold(s, name) {
+			return scope, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid scope '%s'", s)
+}
+
+// LeaderboardFilter narrows a Find query. Zero-valued fields match everything,
+// and the zero value sorts by score, highest first.
+type LeaderboardFilter struct {
+	Scope     LeaderboardScope
 	Mode      GameMode
-	CreatedAt string
+	Name      string    // exact player name
+	Search    string    // case-insensitive substring of the player name
+	Since     time.Time // only entries created at or after this instant
+	OrderBy   LeaderboardOrder
+	Ascending bool
+	Limit     int // maximum number of entries; 0 means no limit
+	Offset    int
+}
+
+// whereClause renders the filter's conditions, including the leading WHERE
+// keyword when there is at least one.
+func (f LeaderboardFilter) whereClause() (string, []any) {
+	var (
+		where []string
+		args  []any
+	)
+	switch f.Scope {
+	case ScopeLocal:
+		where = append(where, "origin = ?")
+		args = append(args, OriginLocal)
+	case ScopeGlobal:
+		where = append(where, "remote_id IS NOT NULL")
+	}
+	if f.Mode != "" {
+		where = append(where, "mode = ?")
+		args = append(args, f.Mode)
+	}
+	if f.Name != "" {
+		where = append(where, "name = ?")
+		args = append(args, f.Name)
+	}
+	if f.Search != "" {
+		where = append(where, "name LIKE ? ESCAPE '\\'")
+		args = append(args, "%"+escapeLike(f.Search)+"%")
+	}
+	if !f.Since.IsZero() {
+		where = append(where, "datetime(created_at) >= datetime(?)")
+		args = append(args, f.Since.UTC().Format(createdAtLayout))
+	}
+
+	if len(where) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(where, " AND "), args
+}
+
+// orderClause renders the ORDER BY clause. The id tie-breaker keeps page
+// boundaries stable when many rows share a value.
+func (f LeaderboardFilter) orderClause() string {
+	dir := "DESC"
+	if f.Ascending {
+		dir = "ASC"
+	}
+	col, ok := orderToColumnMap[f.OrderBy]
+	if !ok {
+		col = orderToColumnMap[OrderByScore]
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id ASC", col, dir)
+}
+
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return r.Replace(s)
+}
+
+// ImportResult summarises the outcome of an Import call.
+type ImportResult struct {
+	Inserted int
+	Skipped  int // duplicates of existing rows or of earlier rows in the batch
 }
 
 type LeaderboardRepository struct {
@@ -49,9 +242,30 @@ func (r *LeaderboardRepository) Save(name string, score, level int, mode GameMod
 	return int(id), nil
 }
 
+// SaveEntry stores a freshly played local entry, including its snake length,
+// and returns its ID. CreatedAt is ignored in favour of the current time.
+func (r *LeaderboardRepository) SaveEntry(e LeaderboardEntry) (int, error) {
+	const query = `
+		INSERT INTO leaderboard (name, score, level, mode, snake_length)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	res, err := r.db.Exec(query, e.Name, e.Score, e.Level, e.Mode, e.SnakeLength)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save leaderboard entry: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve last insert id: %w", err)
+	}
+
+	return int(id), nil
+}
+
 func (r *LeaderboardRepository) All() ([]LeaderboardEntry, error) {
 	const query = `
-		SELECT id, name, score, level, mode, created_at
+		SELECT id, name, score, level, mode, created_at,
+		       snake_length, origin, COALESCE(remote_id, '')
 		FROM leaderboard
 		ORDER BY score DESC
 	`
@@ -65,7 +279,9 @@ func (r *LeaderboardRepository) All() ([]LeaderboardEntry, error) {
 	var entries []LeaderboardEntry
 	for rows.Next() {
 		var e LeaderboardEntry
-		if err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt); err != nil {
+		err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt,
+			&e.SnakeLength, &e.Origin, &e.RemoteID)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
 		}
 		entries = append(entries, e)
@@ -78,23 +294,38 @@ func (r *LeaderboardRepository) All() ([]LeaderboardEntry, error) {
 	return entries, nil
 }
 
+// GetTopN returns the n highest scores across every mode.
 func (r *LeaderboardRepository) GetTopN(n int) ([]LeaderboardEntry, error) {
+	return r.Find(LeaderboardFilter{Limit: n})
+}
+
+// GetTopNByMode returns the n highest scores in mode. It is the first page of
+// the leaderboard view's tab for mode, which pages further through Find with
+// the same ordering.
+func (r *LeaderboardRepository) GetTopNByMode(n int, mode GameMode) ([]LeaderboardEntry, error) {
+	return r.Find(LeaderboardFilter{Mode: mode, Limit: n})
+}
+
+func (r *LeaderboardRepository) GetByName(name string) ([]LeaderboardEntry, error) {
 	const query = `
-		SELECT id, name, score, level, mode, created_at
+		SELECT id, name, score, level, mode, created_at,
+		       snake_length, origin, COALESCE(remote_id, '')
 		FROM leaderboard
+		WHERE name = ?
 		ORDER BY score DESC
-		LIMIT ?
 	`
-	rows, err := r.db.Query(query, n)
+	rows, err := r.db.Query(query, name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
+		return nil, fmt.Errorf("failed to query leaderboard by name: %w", err)
 	}
 	defer rows.Close()
 
 	var entries []LeaderboardEntry
 	for rows.Next() {
 		var e LeaderboardEntry
-		if err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt); err != nil {
+		err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt,
+			&e.SnakeLength, &e.Origin, &e.RemoteID)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
 		}
 		entries = append(entries, e)
@@ -107,53 +338,217 @@ func (r *LeaderboardRepository) GetTopN(n int) ([]LeaderboardEntry, error) {
 	return entries, nil
 }
 
-func (r *LeaderboardRepository) GetTopNByMode(n int, mode GameMode) ([]LeaderboardEntry, error) {
-	const query = `
-		SELECT id, name, score, level, mode, created_at
+// SaveProof attaches a signed run proof and the replay it commits to (both
+// JSON) to a local entry, to be sent along when the entry is submitted.
+func (r *LeaderboardRepository) SaveProof(id int, proof, replay string) error {
+	const query = `UPDATE leaderboard SET proof = ?, replay = NULLIF(?, '') WHERE id = ?`
+	if _, err := r.db.Exec(query, proof, replay, id); err != nil {
+		return fmt.Errorf("failed to save run proof: %w", err)
+	}
+	return nil
+}
+
+func (r *LeaderboardRepository) Delete(id int) error {
+	const query = `DELETE FROM leaderboard WHERE id = ?`
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete leaderboard entry: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to confirm deletion: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("no entry found with id '%d'", id)
+	}
+
+	return nil
+}
+
+// Find returns the entries matching f, highest score first.
+func (r *LeaderboardRepository) Find(f LeaderboardFilter) ([]LeaderboardEntry, error) {
+	where, args := f.whereClause()
+
+	query := `
+		SELECT id, name, score, level, mode, created_at,
+		       snake_length, origin, COALESCE(remote_id, '')
 		FROM leaderboard
-		WHERE mode = ?
-		ORDER BY score DESC
-		LIMIT ?
+	` + where + f.orderClause()
+	if f.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, f.Limit, f.Offset)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+// Count returns how many entries match f, ignoring its Limit and Offset.
+func (r *LeaderboardRepository) Count(f LeaderboardFilter) (int, error) {
+	where, args := f.whereClause()
+
+	var n int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM leaderboard"+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count leaderboard entries: %w", err)
+	}
+	return n, nil
+}
+
+// Position returns the zero-based index of entry id within the ordered
+// results of f, ignoring its Limit and Offset. ok is false when the entry
+// does not match the filter.
+func (r *LeaderboardRepository) Position(f LeaderboardFilter, id int) (pos int, ok bool, err error) {
+	where, args := f.whereClause()
+
+	query := `
+		SELECT rn FROM (
+			SELECT id, ROW_NUMBER() OVER (` + f.orderClause() + `) - 1 AS rn
+			FROM leaderboard
+		` + where + `
+		) WHERE id = ?
 	`
-	rows, err := r.db.Query(query, mode, n)
+	args = append(args, id)
+
+	err = r.db.QueryRow(query, args...).Scan(&pos)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to query leaderboard by mode: %w", err)
+		return 0, false, fmt.Errorf("failed to locate leaderboard entry: %w", err)
+	}
+	return pos, true, nil
+}
+
+// Names returns every distinct player name, alphabetically.
+func (r *LeaderboardRepository) Names() ([]string, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT name FROM leaderboard ORDER BY name COLLATE NOCASE`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player names: %w", err)
 	}
 	defer rows.Close()
 
-	var entries []LeaderboardEntry
+	var names []string
 	for rows.Next() {
-		var e LeaderboardEntry
-		if err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan player name: %w", err)
 		}
-		entries = append(entries, e)
+		names = append(names, name)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("leaderboard row iteration error: %w", err)
+		return nil, fmt.Errorf("player name iteration error: %w", err)
 	}
 
-	return entries, nil
+	return names, nil
 }
 
-func (r *LeaderboardRepository) GetByName(name string) ([]LeaderboardEntry, error) {
-	const query = `
-		SELECT id, name, score, level, mode, created_at
-		FROM leaderboard
-		WHERE name = ?
-		ORDER BY score DESC
-	`
-	rows, err := r.db.Query(query, name)
+// Import inserts entries in a single transaction. Every entry is validated
+// before anything is written; rows identical to an existing entry (same name,
+// score, level, mode and timestamp) are skipped so the same file can be
+// imported more than once.
+//
+// Rows without a timestamp are stamped with the import time and marked
+// undated. They are only compared with other undated rows, by count: a file
+// holding the same score twice imports both, and importing it again adds
+// nothing.
+func (r *LeaderboardRepository) Import(entries []LeaderboardEntry) (ImportResult, error) {
+	var res ImportResult
+
+	for i, e := range entries {
+		if err := e.Validate(); err != nil {
+			return res, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+	}
+
+	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to query leaderboard by name: %w", err)
+		return res, fmt.Errorf("failed to begin import: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
+
+	const existsQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM leaderboard
+			WHERE name = ? AND score = ? AND level = ? AND mode = ?
+			  AND undated = 0 AND datetime(created_at) = datetime(?)
+		)
+	`
+	const countUndatedQuery = `
+		SELECT COUNT(*) FROM leaderboard
+		WHERE name = ? AND score = ? AND level = ? AND mode = ? AND undated = 1
+	`
+	const insertQuery = `
+		INSERT INTO leaderboard (name, score, level, mode, created_at, undated)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	type runKey struct {
+		name         string
+		score, level int
+		mode         GameMode
+	}
+	// Undated rows already stored, and seen so far in entries, per run.
+	stored := map[runKey]int{}
+	seen := map[runKey]int{}
+
+	for _, e := range entries {
+		mode, _ := ParseGameMode(string(e.Mode))
+
+		undated := e.CreatedAt == ""
+		createdAt := time.Now().UTC()
+		if !undated {
+			createdAt, _ = ParseTimestamp(e.CreatedAt)
+		}
+		ts := createdAt.Format(createdAtLayout)
+
+		var exists bool
+		if undated {
+			key := runKey{e.Name, e.Score, e.Level, mode}
+			n, ok := stored[key]
+			if !ok {
+				if err := tx.QueryRow(countUndatedQuery, e.Name, e.Score, e.Level, mode).Scan(&n); err != nil {
+					return res, fmt.Errorf("failed to check for duplicate entry: %w", err)
+				}
+				stored[key] = n
+			}
+			seen[key]++
+			exists = seen[key] <= n
+		} else if err := tx.QueryRow(existsQuery, e.Name, e.Score, e.Level, mode, ts).Scan(&exists); err != nil {
+			return res, fmt.Errorf("failed to check for duplicate entry: %w", err)
+		}
+		if exists {
+			res.Skipped++
+			continue
+		}
 
+		if _, err := tx.Exec(insertQuery, e.Name, e.Score, e.Level, mode, ts, undated); err != nil {
+			return res, fmt.Errorf("failed to import leaderboard entry: %w", err)
+		}
+		res.Inserted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return res, nil
+}
+
+// scanEntries drains rows into a slice of entries.
+func scanEntries(rows *sql.Rows) ([]LeaderboardEntry, error) {
 	var entries []LeaderboardEntry
 	for rows.Next() {
 		var e LeaderboardEntry
-		if err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt); err != nil {
+		err := rows.Scan(&e.ID, &e.Name, &e.Score, &e.Level, &e.Mode, &e.CreatedAt,
+			&e.SnakeLength, &e.Origin, &e.RemoteID)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
 		}
 		entries = append(entries, e)
@@ -165,21 +560,3 @@ func (r *LeaderboardRepository) GetByName(name string) ([]LeaderboardEntry, erro
 
 	return entries, nil
 }
-
-func (r *LeaderboardRepository) Delete(id int) error {
-	const query = `DELETE FROM leaderboard WHERE id = ?`
-	res, err := r.db.Exec(query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete leaderboard entry: %w", err)
-	}
-
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to confirm deletion: %w", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("no entry found with id '%d'", id)
-	}
-
-	return nil
-}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLeaderboardImport(t *testing.T) {
+	dated := LeaderboardEntry{Name: "ada", Score: 120, Level: 2, Mode: GameModeNormal, CreatedAt: "2026-01-02 03:04:05"}
+	undated := LeaderboardEntry{Name: "ada", Score: 120, Level: 2, Mode: GameModeNormal}
+	other := LeaderboardEntry{Name: "grace", Score: 300, Level: 3, Mode: GameModeNormal}
+
+	tests := []struct {
+		name   string
+		first  []LeaderboardEntry
+		second []LeaderboardEntry
+
+		want ImportResult // result of the second import
+	}{
+		{name: "dated re-import", first: []LeaderboardEntry{dated, other}, second: []LeaderboardEntry{dated, other},
+			want: ImportResult{Skipped: 2}},
+		{name: "undated re-import", first: []LeaderboardEntry{undated, other}, second: []LeaderboardEntry{undated, other},
+			want: ImportResult{Skipped: 2}},
+		{name: "undated copy of a dated entry", first: []LeaderboardEntry{dated}, second: []LeaderboardEntry{undated},
+			want: ImportResult{Inserted: 1}},
+		{name: "dated entry after an undated one", first: []LeaderboardEntry{undated}, second: []LeaderboardEntry{dated},
+			want: ImportResult{Inserted: 1}},
+		{name: "undated repeat within a file", second: []LeaderboardEntry{undated, undated},
+			want: ImportResult{Inserted: 2}},
+		{name: "undated repeats re-imported", first: []LeaderboardEntry{undated, undated}, second: []LeaderboardEntry{undated, undated},
+			want: ImportResult{Skipped: 2}},
+		{name: "one more undated repeat", first: []LeaderboardEntry{undated, undated}, second: []LeaderboardEntry{undated, undated, undated},
+			want: ImportResult{Inserted: 1, Skipped: 2}},
+		{name: "different run", first: []LeaderboardEntry{undated}, second: []LeaderboardEntry{other},
+			want: ImportResult{Inserted: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := NewDB(filepath.Join(t.TempDir(), "gosnake.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			repo := NewLeaderboardRepository(db)
+
+			if _, err := repo.Import(tt.first); err != nil {
+				t.Fatal(err)
+			}
+			got, err := repo.Import(tt.second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Import() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaderboardGetTopNByMode(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "gosnake.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := NewLeaderboardRepository(db)
+
+	runs := []LeaderboardEntry{
+		{Name: "ada", Score: 120, Level: 1, Mode: GameModeNormal},
+		{Name: "grace", Score: 300, Level: 2, Mode: GameModeNormal},
+		{Name: "linus", Score: 300, Level: 2, Mode: GameModeNormal},
+		{Name: "ken", Score: 90, Level: 1, Mode: GameModeNormal},
+		{Name: "ada", Score: 500, Level: 3, Mode: GameModeCrazy},
+	}
+	for _, e := range runs {
+		if _, err := repo.SaveEntry(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		mode GameMode
+		want []string // names, best first; ties in insertion order
+	}{
+		{name: "normal", n: 3, mode: GameModeNormal, want: []string{"grace", "linus", "ada"}},
+		{name: "more than there are", n: 10, mode: GameModeCrazy, want: []string{"ada"}},
+		{name: "empty mode", n: 3, mode: GameModeAI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			top, err := repo.GetTopNByMode(tt.n, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, e := range top {
+				if e.Mode != tt.mode {
+					t.Errorf("%s's entry is in mode %q, want %q", e.Name, e.Mode, tt.mode)
+				}
+				names = append(names, e.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Fatalf("GetTopNByMode(%d, %q) = %v, want %v", tt.n, tt.mode, names, tt.want)
+			}
+
+			// The leaderboard view pages on from here with Find.
+			next, err := repo.Find(LeaderboardFilter{Mode: tt.mode, Limit: tt.n, Offset: tt.n})
+			if err != nil {
+				t.Fatal(err)
+			}
+			all, err := repo.Count(LeaderboardFilter{Mode: tt.mode})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(top)+len(next) != min(all, 2*tt.n) {
+				t.Errorf("first two pages hold %d entries, want %d", len(top)+len(next), min(all, 2*tt.n))
+			}
+			for _, e := range next {
+				if len(top) > 0 && e.Score > top[len(top)-1].Score {
+					t.Errorf("second page has %s on %d, above the first page", e.Name, e.Score)
+				}
+			}
+		})
+	}
+}
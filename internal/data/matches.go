@@ -0,0 +1,283 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultRating is the rating a player starts with.
+	DefaultRating = 1500
+
+	// eloK is the most a rating can move in a two-player match. In larger
+	// matches it is spread over the opponents, so a match is worth the same
+	// at any size.
+	eloK = 32
+)
+
+// MatchPlayer is one player's result in a multiplayer match.
+type MatchPlayer struct {
+	Fingerprint string // SSH public key fingerprint, the player's identity
+	Name        string
+	Score       int
+	Length      int
+	Place       int // 1 for the winner; players who tie share a place
+}
+
+// Match is a finished multiplayer match to be recorded.
+type Match struct {
+	Room    string
+	Mode    string
+	Players []MatchPlayer
+}
+
+// RatingChange is how a recorded match moved one player's rating.
+type RatingChange struct {
+	Fingerprint string
+	Before      int
+	After       int
+}
+
+// PlayerRating is a player's standing on the server.
+type PlayerRating struct {
+	Fingerprint string
+	Name        string // name used in the player's latest match
+	Rating      int
+	Matches     int
+	Wins        int
+}
+
+// MatchHistoryEntry is one match from a player's point of view.
+type MatchHistoryEntry struct {
+	MatchID      int
+	Room         string
+	Mode         string
+	Players      int
+	Place        int
+	Score        int
+	Rating       int // rating after the match
+	RatingChange int
+	PlayedAt     string
+}
+
+// MatchRepository stores multiplayer match results and the ratings derived
+// from them.
+type MatchRepository struct {
+	db *sql.DB
+}
+
+func NewMatchRepository(db *sql.DB) *MatchRepository {
+	return &MatchRepository{db}
+}
+
+// Record stores m and updates every player's rating with a multiplayer Elo:
+// each player is scored against every other player by place.
+func (r *MatchRepository) Record(m Match) ([]RatingChange, error) {
+	if len(m.Players) < 2 {
+		return nil, errors.New("a match needs at least two players")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin recording match: %w", err)
+	}
+	defer tx.Rollback()
+
+	ratings := make([]float64, len(m.Players))
+	for i, p := range m.Players {
+		err := tx.QueryRow(`SELECT rating FROM mp_players WHERE fingerprint = ?`, p.Fingerprint).Scan(&ratings[i])
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			ratings[i] = DefaultRating
+		case err != nil:
+			return nil, fmt.Errorf("failed to read rating: %w", err)
+		}
+	}
+
+	places := make([]int, len(m.Players))
+	for i, p := range m.Players {
+		places[i] = p.Place
+	}
+	deltas := eloDeltas(ratings, places)
+
+	res, err := tx.Exec(`INSERT INTO mp_matches (room, mode, players) VALUES (?, ?, ?)`,
+		m.Room, m.Mode, len(m.Players))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save match: %w", err)
+	}
+	matchID, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve last insert id: %w", err)
+	}
+
+	const upsertPlayer = `
+		INSERT INTO mp_players (fingerprint, name, rating, matches, wins)
+		VALUES (?, ?, ?, 1, ?)
+		ON CONFLICT (fingerprint) DO UPDATE SET
+			name       = excluded.name,
+			rating     = excluded.rating,
+			matches    = matches + 1,
+			wins       = wins + excluded.wins,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	const insertResult = `
+		INSERT INTO mp_results (match_id, fingerprint, name, score, length, place, rating_before, rating_after)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	changes := make([]RatingChange, len(m.Players))
+	for i, p := range m.Players {
+		after := ratings[i] + deltas[i]
+		win := 0
+		if p.Place == 1 {
+			win = 1
+		}
+
+		if _, err := tx.Exec(upsertPlayer, p.Fingerprint, p.Name, after, win); err != nil {
+			return nil, fmt.Errorf("failed to update rating: %w", err)
+		}
+		if _, err := tx.Exec(insertResult, matchID, p.Fingerprint, p.Name, p.Score, p.Length,
+			p.Place, ratings[i], after); err != nil {
+			return nil, fmt.Errorf("failed to save match result: %w", err)
+		}
+
+		changes[i] = RatingChange{
+			Fingerprint: p.Fingerprint,
+			Before:      int(math.Round(ratings[i])),
+			After:       int(math.Round(after)),
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit match: %w", err)
+	}
+	return changes, nil
+}
+
+// TopRatings returns the n highest-rated players.
+func (r *MatchRepository) TopRatings(n int) ([]PlayerRating, error) {
+	const query = `
+		SELECT fingerprint, name, rating, matches, wins
+		FROM mp_players
+		ORDER BY rating DESC, matches DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []PlayerRating
+	for rows.Next() {
+		var (
+			p      PlayerRating
+			rating float64
+		)
+		if err := rows.Scan(&p.Fingerprint, &p.Name, &rating, &p.Matches, &p.Wins); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		p.Rating = int(math.Round(rating))
+		ratings = append(ratings, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rating row iteration error: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// Rating returns a player's standing, or the default rating if they have not
+// finished a match yet.
+func (r *MatchRepository) Rating(fingerprint string) (PlayerRating, error) {
+	const query = `
+		SELECT fingerprint, name, rating, matches, wins
+		FROM mp_players
+		WHERE fingerprint = ?
+	`
+	var (
+		p      PlayerRating
+		rating float64
+	)
+	err := r.db.QueryRow(query, fingerprint).Scan(&p.Fingerprint, &p.Name, &rating, &p.Matches, &p.Wins)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return PlayerRating{Fingerprint: fingerprint, Rating: DefaultRating}, nil
+	case err != nil:
+		return p, fmt.Errorf("failed to query rating: %w", err)
+	}
+	p.Rating = int(math.Round(rating))
+	return p, nil
+}
+
+// History returns a player's n most recent matches, newest first.
+func (r *MatchRepository) History(fingerprint string, n int) ([]MatchHistoryEntry, error) {
+	const query = `
+		SELECT m.id, m.room, m.mode, m.players, r.place, r.score,
+		       r.rating_before, r.rating_after, m.created_at
+		FROM mp_results r
+		JOIN mp_matches m ON m.id = r.match_id
+		WHERE r.fingerprint = ?
+		ORDER BY m.id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, fingerprint, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []MatchHistoryEntry
+	for rows.Next() {
+		var (
+			e             MatchHistoryEntry
+			before, after float64
+		)
+		err := rows.Scan(&e.MatchID, &e.Room, &e.Mode, &e.Players, &e.Place, &e.Score,
+			&before, &after, &e.PlayedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan match history: %w", err)
+		}
+		e.Rating = int(math.Round(after))
+		e.RatingChange = e.Rating - int(math.Round(before))
+		history = append(history, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("match history row iteration error: %w", err)
+	}
+
+	return history, nil
+}
+
+// eloDeltas returns each player's rating change. Every pair of players is
+// treated as a game won by the better place (or drawn on a tie).
+func eloDeltas(ratings []float64, places []int) []float64 {
+	n := len(ratings)
+	deltas := make([]float64, n)
+	if n < 2 {
+		return deltas
+	}
+
+	k := float64(eloK) / float64(n-1)
+	for i := range n {
+		for j := range n {
+			if i == j {
+				continue
+			}
+			expected := 1 / (1 + math.Pow(10, (ratings[j]-ratings[i])/400))
+			actual := 0.5
+			switch {
+			case places[i] < places[j]:
+				actual = 1
+			case places[i] > places[j]:
+				actual = 0
+			}
+			deltas[i] += k * (actual - expected)
+		}
+	}
+	return deltas
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEloDeltas(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings []float64
+		places  []int
+		want    []float64
+	}{
+		{name: "alone", ratings: []float64{1500}, places: []int{1}, want: []float64{0}},
+		{name: "even win", ratings: []float64{1500, 1500}, places: []int{1, 2}, want: []float64{16, -16}},
+		{name: "even draw", ratings: []float64{1500, 1500}, places: []int{1, 1}, want: []float64{0, 0}},
+		// A 400 point gap makes the favourite ten times as likely to win.
+		{name: "favourite wins", ratings: []float64{1900, 1500}, places: []int{1, 2}, want: []float64{32.0 / 11, -32.0 / 11}},
+		{name: "upset", ratings: []float64{1900, 1500}, places: []int{2, 1}, want: []float64{-320.0 / 11, 320.0 / 11}},
+		{name: "uneven draw", ratings: []float64{1900, 1500}, places: []int{1, 1}, want: []float64{-144.0 / 11, 144.0 / 11}},
+		// K is shared between the opponents, so a sweep is still worth K.
+		{name: "three even", ratings: []float64{1500, 1500, 1500}, places: []int{1, 2, 3}, want: []float64{16, 0, -16}},
+		{name: "four even, shared second", ratings: []float64{1500, 1500, 1500, 1500}, places: []int{1, 2, 2, 4},
+			want: []float64{16, 0, 0, -16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eloDeltas(tt.ratings, tt.places)
+			if len(got) != len(tt.want) {
+				t.Fatalf("eloDeltas() returned %d deltas, want %d", len(got), len(tt.want))
+			}
+
+			var sum float64
+			for i := range got {
+				sum += got[i]
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("delta %d = %.4f, want %.4f", i, got[i], tt.want[i])
+				}
+			}
+			if math.Abs(sum) > 1e-9 {
+				t.Errorf("deltas sum to %.4f; rating points should only move between players", sum)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package data
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// PendingScore is a finished run waiting to be submitted to the remote
+// leaderboard.
+type PendingScore struct {
+	ID             int
+	EntryID        int // local leaderboard row this submission came from; 0 if none
+	IdempotencyKey string
+	Name           string
+	Score          int
+	Level          int
+	SnakeLength    int
+	Mode           GameMode
+	PlayedAt       string // when the run was played; empty if unknown
+	Proof          string // signed run proof (JSON) of the entry; empty if unsigned
+	Replay         string // replay (JSON) the proof commits to; empty if unsigned
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+}
+
+// OutboxRepository is a durable queue of score submissions, so runs finished
+// while offline are retried on a later launch.
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db}
+}
+
+// Enqueue stores a new pending submission, due immediately, and assigns it a
+// random idempotency key.
+func (r *OutboxRepository) Enqueue(p PendingScore) (PendingScore, error) {
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return p, err
+	}
+	p.IdempotencyKey = key
+
+	const query = `
+		INSERT INTO score_outbox (idempotency_key, entry_id, name, score, level, snake_length, mode, played_at)
+		VALUES (?, NULLIF(?, 0), ?, ?, ?, ?, ?, NULLIF(?, ''))
+	`
+	res, err := r.db.Exec(query, p.IdempotencyKey, p.EntryID, p.Name, p.Score, p.Level,
+		p.SnakeLength, p.Mode, p.PlayedAt)
+	if err != nil {
+		return p, fmt.Errorf("failed to enqueue score: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return p, fmt.Errorf("failed to retrieve last insert id: %w", err)
+	}
+	p.ID = int(id)
+
+	return p, nil
+}
+
+// Due returns up to limit submissions whose next attempt is at or before now,
+// oldest first.
+func (r *OutboxRepository) Due(now time.Time, limit int) ([]PendingScore, error) {
+	const query = `
+		SELECT o.id, COALESCE(o.entry_id, 0), o.idempotency_key, o.name, o.score, o.level,
+		       o.snake_length, o.mode, COALESCE(o.played_at, ''), o.attempts, o.next_attempt_at,
+		       o.last_error, COALESCE(l.proof, ''), COALESCE(l.replay, '')
+		FROM score_outbox o
+		LEFT JOIN leaderboard l ON l.id = o.entry_id
+		WHERE datetime(o.next_attempt_at) <= datetime(?)
+		ORDER BY o.id ASC
+		LIMIT ?
+	`
+	rows, err := r.db.Query(query, now.UTC().Format(createdAtLayout), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query score outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []PendingScore
+	for rows.Next() {
+		var (
+			p    PendingScore
+			next string
+		)
+		err := rows.Scan(&p.ID, &p.EntryID, &p.IdempotencyKey, &p.Name, &p.Score, &p.Level,
+			&p.SnakeLength, &p.Mode, &p.PlayedAt, &p.Attempts, &next, &p.LastError, &p.Proof, &p.Replay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending score: %w", err)
+		}
+		p.NextAttemptAt, _ = ParseTimestamp(next)
+		pending = append(pending, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("score outbox row iteration error: %w", err)
+	}
+
+	return pending, nil
+}
+
+// Reschedule records a failed attempt and when to try again.
+func (r *OutboxRepository) Reschedule(id, attempts int, next time.Time, lastErr string) error {
+	const query = `
+		UPDATE score_outbox
+		SET attempts = ?, next_attempt_at = ?, last_error = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query, attempts, next.UTC().Format(createdAtLayout), lastErr, id)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule pending score: %w", err)
+	}
+	return nil
+}
+
+// Complete removes a submission the server has accepted and, when it came
+// from a local leaderboard row, records the remote entry ID on that row.
+func (r *OutboxRepository) Complete(p PendingScore, remoteID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin completing pending score: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM score_outbox WHERE id = ?`, p.ID); err != nil {
+		return fmt.Errorf("failed to delete pending score: %w", err)
+	}
+
+	if p.EntryID != 0 && remoteID != "" {
+		// A concurrent pull may already have linked this remote entry.
+		const query = `
+			UPDATE leaderboard SET remote_id = ?
+			WHERE id = ? AND remote_id IS NULL
+			  AND NOT EXISTS (SELECT 1 FROM leaderboard WHERE remote_id = ?)
+		`
+		if _, err := tx.Exec(query, remoteID, p.EntryID, remoteID); err != nil {
+			return fmt.Errorf("failed to link synced entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit completed score: %w", err)
+	}
+	return nil
+}
+
+// PendingEntryIDs returns the local leaderboard rows that already have a
+// submission queued.
+func (r *OutboxRepository) PendingEntryIDs() (map[int]bool, error) {
+	rows, err := r.db.Query(`SELECT entry_id FROM score_outbox WHERE entry_id IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan pending entry: %w", err)
+		}
+		ids[id] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pending entry iteration error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Reject removes a submission the server has permanently rejected and, when
+// it came from a local leaderboard row, marks that row so it is never queued
+// again.
+func (r *OutboxRepository) Reject(p PendingScore) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin rejecting pending score: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM score_outbox WHERE id = ?`, p.ID); err != nil {
+		return fmt.Errorf("failed to delete pending score: %w", err)
+	}
+
+	if p.EntryID != 0 {
+		const query = `UPDATE leaderboard SET rejected_at = ? WHERE id = ?`
+		now := time.Now().UTC().Format(createdAtLayout)
+		if _, err := tx.Exec(query, now, p.EntryID); err != nil {
+			return fmt.Errorf("failed to mark rejected entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rejected score: %w", err)
+	}
+	return nil
+}
+
+// Count returns how many submissions are still waiting to be sent.
+func (r *OutboxRepository) Count() (int, error) {
+	var n int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM score_outbox`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count pending scores: %w", err)
+	}
+	return n, nil
+}
+
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
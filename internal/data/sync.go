@@ -0,0 +1,179 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+// MergeResult summarises the outcome of a MergeRemote call.
+type MergeResult struct {
+	Inserted  int // new rows pulled from the remote leaderboard
+	Linked    int // local rows matched to their remote copy
+	Unchanged int // remote entries already known locally
+	Skipped   int // remote entries that failed validation
+}
+
+// MergeRemote records entries fetched from the remote leaderboard in a single
+// transaction. Each entry must carry its RemoteID. An entry whose RemoteID is
+// already known is left alone; one that matches an unsynced local row (same
+// name, score, level, mode and timestamp) links that row; anything else is
+// inserted with OriginRemote. An empty Mode matches any mode and is stored as
+// GameModeNormal.
+func (r *LeaderboardRepository) MergeRemote(entries []LeaderboardEntry) (MergeResult, error) {
+	var res MergeResult
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return res, fmt.Errorf("failed to begin merge: %w", err)
+	}
+	defer tx.Rollback()
+
+	const knownQuery = `SELECT EXISTS (SELECT 1 FROM leaderboard WHERE remote_id = ?)`
+	const linkQuery = `
+		UPDATE leaderboard SET remote_id = ?
+		WHERE id = (
+			SELECT id FROM leaderboard
+			WHERE remote_id IS NULL AND origin = ?
+			  AND name = ? AND score = ? AND level = ? AND (? = '' OR mode = ?)
+			  AND datetime(created_at) = datetime(?)
+			ORDER BY id ASC
+			LIMIT 1
+		)
+	`
+	const insertQuery = `
+		INSERT INTO leaderboard (name, score, level, mode, created_at, snake_length, origin, remote_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	for _, e := range entries {
+		if e.RemoteID == "" {
+			res.Skipped++
+			continue
+		}
+
+		var mode GameMode
+		if e.Mode != "" {
+			if mode, err = ParseGameMode(string(e.Mode)); err != nil {
+				res.Skipped++
+				continue
+			}
+		}
+		e.Mode = mode
+		if e.Mode == "" {
+			e.Mode = GameModeNormal
+		}
+		if err := e.Validate(); err != nil {
+			res.Skipped++
+			continue
+		}
+
+		var known bool
+		if err := tx.QueryRow(knownQuery, e.RemoteID).Scan(&known); err != nil {
+			return res, fmt.Errorf("failed to look up remote entry: %w", err)
+		}
+		if known {
+			res.Unchanged++
+			continue
+		}
+
+		createdAt := time.Now().UTC()
+		if e.CreatedAt != "" {
+			createdAt, _ = ParseTimestamp(e.CreatedAt)
+		}
+		ts := createdAt.Format(createdAtLayout)
+
+		linked, err := tx.Exec(linkQuery, e.RemoteID, OriginLocal,
+			e.Name, e.Score, e.Level, mode, mode, ts)
+		if err != nil {
+			return res, fmt.Errorf("failed to link remote entry: %w", err)
+		}
+		if n, _ := linked.RowsAffected(); n > 0 {
+			res.Linked++
+			continue
+		}
+
+		_, err = tx.Exec(insertQuery, e.Name, e.Score, e.Level, e.Mode, ts,
+			e.SnakeLength, OriginRemote, e.RemoteID)
+		if err != nil {
+			return res, fmt.Errorf("failed to insert remote entry: %w", err)
+		}
+		res.Inserted++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return MergeResult{}, fmt.Errorf("failed to commit merge: %w", err)
+	}
+
+	return res, nil
+}
+
+// Unsynced returns local rows that have not reached the remote leaderboard
+// yet, oldest first. Rows the server has rejected are left out.
+func (r *LeaderboardRepository) Unsynced() ([]LeaderboardEntry, error) {
+	const query = `
+		SELECT id, name, score, level, mode, created_at,
+		       snake_length, origin, COALESCE(remote_id, '')
+		FROM leaderboard
+		WHERE origin = ? AND remote_id IS NULL AND rejected_at IS NULL
+		ORDER BY id ASC
+	`
+	rows, err := r.db.Query(query, OriginLocal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unsynced entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEntries(rows)
+}
+
+// CountUnsynced returns how many local rows have not reached the remote
+// leaderboard yet.
+func (r *LeaderboardRepository) CountUnsynced() (int, error) {
+	const query = `
+		SELECT COUNT(*) FROM leaderboard
+		WHERE origin = ? AND remote_id IS NULL AND rejected_at IS NULL
+	`
+
+	var n int
+	if err := r.db.QueryRow(query, OriginLocal).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count unsynced entries: %w", err)
+	}
+	return n, nil
+}
+
+// FindByRemoteID returns the local row linked to a remote entry.
+func (r *LeaderboardRepository) FindByRemoteID(remoteID string) (LeaderboardEntry, bool, error) {
+	const query = `
+		SELECT id, name, score, level, mode, created_at,
+		       snake_length, origin, COALESCE(remote_id, '')
+		FROM leaderboard
+		WHERE remote_id = ?
+	`
+	rows, err := r.db.Query(query, remoteID)
+	if err != nil {
+		return LeaderboardEntry{}, false, fmt.Errorf("failed to query remote entry: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntries(rows)
+	if err != nil || len(entries) == 0 {
+		return LeaderboardEntry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+// DeleteRemote removes the mirrored copy of a remote entry that was deleted
+// on the server. Rows that originated locally are kept, and stay linked so
+// the deleted score is not pushed again.
+func (r *LeaderboardRepository) DeleteRemote(remoteID string) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM leaderboard WHERE remote_id = ? AND origin = ?`, remoteID, OriginRemote)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete remote entry: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to confirm deletion: %w", err)
+	}
+	return n > 0, nil
+}
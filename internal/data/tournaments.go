@@ -0,0 +1,233 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Tournament formats.
+const (
+	SingleElimination = "single"
+	DoubleElimination = "double"
+)
+
+// Tournament states.
+const (
+	TournamentRegistering = "registering"
+	TournamentRunning     = "running"
+	TournamentFinished    = "finished"
+)
+
+// Bracket sections a tournament match can belong to.
+const (
+	BracketWinners = "winners"
+	BracketLosers  = "losers"
+	BracketFinal   = "final"
+)
+
+// ErrTournamentNotFound is returned when no tournament has the given id.
+var ErrTournamentNotFound = errors.New("tournament not found")
+
+// Tournament is a bracket tournament hosted by the multiplayer server.
+type Tournament struct {
+	ID        string
+	Format    string // SingleElimination or DoubleElimination
+	Status    string
+	Organiser string // fingerprint of the key that created it
+	Champion  string // winner's name once finished
+	Players   []TournamentPlayer
+	Matches   []TournamentMatch // by ID, starting at 1
+}
+
+// TournamentPlayer is an entrant. Players named by the organiser have no
+// fingerprint until they first sit down for a match; from then on only that
+// key may play under the name.
+type TournamentPlayer struct {
+	Seed        int
+	Name        string
+	Fingerprint string
+}
+
+// TournamentMatch is one pairing in the bracket. Empty player slots are
+// still to be decided by earlier matches. Next and LoserNext name the matches
+// the winner and loser move on to (0 for none) and the slot they take there.
+type TournamentMatch struct {
+	ID        int
+	Bracket   string
+	Round     int
+	Players   [2]string
+	Winner    string
+	Next      int
+	NextSlot  int
+	LoserNext int
+	LoserSlot int
+}
+
+// TournamentRepository stores tournaments and their brackets so they survive
+// a server restart.
+type TournamentRepository struct {
+	db *sql.DB
+}
+
+func NewTournamentRepository(db *sql.DB) *TournamentRepository {
+	return &TournamentRepository{db}
+}
+
+// Save stores t, replacing whatever was stored under its id.
+func (r *TournamentRepository) Save(t Tournament) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin saving tournament: %w", err)
+	}
+	defer tx.Rollback()
+
+	const upsertTournament = `
+		INSERT INTO mp_tournaments (id, format, status, organiser, champion)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			format     = excluded.format,
+			status     = excluded.status,
+			organiser  = excluded.organiser,
+			champion   = excluded.champion,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	if _, err := tx.Exec(upsertTournament, t.ID, t.Format, t.Status, t.Organiser, t.Champion); err != nil {
+		return fmt.Errorf("failed to save tournament: %w", err)
+	}
+
+	for _, table := range []string{"mp_tournament_players", "mp_tournament_matches"} {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE tournament_id = ?", table), t.ID); err != nil {
+			return fmt.Errorf("failed to clear tournament %s: %w", table, err)
+		}
+	}
+
+	for _, p := range t.Players {
+		_, err := tx.Exec(`INSERT INTO mp_tournament_players (tournament_id, seed, name, fingerprint) VALUES (?, ?, ?, ?)`,
+			t.ID, p.Seed, p.Name, p.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("failed to save tournament player: %w", err)
+		}
+	}
+
+	const insertMatch = `
+		INSERT INTO mp_tournament_matches (tournament_id, id, bracket, round, player_a, player_b,
+			winner, next_match, next_slot, loser_match, loser_slot)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, m := range t.Matches {
+		_, err := tx.Exec(insertMatch, t.ID, m.ID, m.Bracket, m.Round, m.Players[0], m.Players[1],
+			m.Winner, m.Next, m.NextSlot, m.LoserNext, m.LoserSlot)
+		if err != nil {
+			return fmt.Errorf("failed to save tournament match: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tournament: %w", err)
+	}
+	return nil
+}
+
+// Find returns the tournament stored under id, or ErrTournamentNotFound.
+func (r *TournamentRepository) Find(id string) (Tournament, error) {
+	var t Tournament
+	err := r.db.QueryRow(`SELECT id, format, status, organiser, champion FROM mp_tournaments WHERE id = ?`, id).
+		Scan(&t.ID, &t.Format, &t.Status, &t.Organiser, &t.Champion)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return t, ErrTournamentNotFound
+	case err != nil:
+		return t, fmt.Errorf("failed to query tournament: %w", err)
+	}
+
+	if t.Players, err = r.players(id); err != nil {
+		return t, err
+	}
+	if t.Matches, err = r.matches(id); err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// Unfinished returns every tournament still registering or being played.
+func (r *TournamentRepository) Unfinished() ([]Tournament, error) {
+	rows, err := r.db.Query(`SELECT id FROM mp_tournaments WHERE status != ? ORDER BY created_at`, TournamentFinished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournaments: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan tournament: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("tournament row iteration error: %w", err)
+	}
+	rows.Close()
+
+	tournaments := make([]Tournament, 0, len(ids))
+	for _, id := range ids {
+		t, err := r.Find(id)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, t)
+	}
+	return tournaments, nil
+}
+
+func (r *TournamentRepository) players(id string) ([]TournamentPlayer, error) {
+	rows, err := r.db.Query(`SELECT seed, name, fingerprint FROM mp_tournament_players WHERE tournament_id = ? ORDER BY seed`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournament players: %w", err)
+	}
+	defer rows.Close()
+
+	var players []TournamentPlayer
+	for rows.Next() {
+		var p TournamentPlayer
+		if err := rows.Scan(&p.Seed, &p.Name, &p.Fingerprint); err != nil {
+			return nil, fmt.Errorf("failed to scan tournament player: %w", err)
+		}
+		players = append(players, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("tournament player row iteration error: %w", err)
+	}
+	return players, nil
+}
+
+func (r *TournamentRepository) matches(id string) ([]TournamentMatch, error) {
+	const query = `
+		SELECT id, bracket, round, player_a, player_b, winner, next_match, next_slot, loser_match, loser_slot
+		FROM mp_tournament_matches
+		WHERE tournament_id = ?
+		ORDER BY id
+	`
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournament matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []TournamentMatch
+	for rows.Next() {
+		var m TournamentMatch
+		err := rows.Scan(&m.ID, &m.Bracket, &m.Round, &m.Players[0], &m.Players[1], &m.Winner,
+			&m.Next, &m.NextSlot, &m.LoserNext, &m.LoserSlot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tournament match: %w", err)
+		}
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("tournament match row iteration error: %w", err)
+	}
+	return matches, nil
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TransferFormat is a file format leaderboard entries can be exported to and
+// imported from.
+type TransferFormat string
+
+const (
+	FormatCSV  TransferFormat = "csv"
+	FormatJSON TransferFormat = "json"
+)
+
+var csvHeader = []string{"id", "name", "score", "level", "mode", "created_at"}
+
+// FormatFromPath infers the transfer format from a file extension.
+func FormatFromPath(path string) (TransferFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("cannot infer format from '%s', expected .csv or .json", path)
+	}
+}
+
+// WriteEntries encodes entries to w in the given format.
+func WriteEntries(w io.Writer, format TransferFormat, entries []LeaderboardEntry) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, entries)
+	case FormatJSON:
+		if entries == nil {
+			entries = []LeaderboardEntry{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entries); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format '%s'", format)
+	}
+}
+
+// ReadEntries decodes entries previously written by WriteEntries.
+func ReadEntries(r io.Reader, format TransferFormat) ([]LeaderboardEntry, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSON:
+		var entries []LeaderboardEntry
+		if err := json.NewDecoder(r).Decode(&entries); err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		return entries, nil
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'", format)
+	}
+}
+
+func writeCSV(w io.Writer, entries []LeaderboardEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	for _, e := range entries {
+		record := []string{
+			strconv.Itoa(e.ID),
+			e.Name,
+			strconv.Itoa(e.Score),
+			strconv.Itoa(e.Level),
+			string(e.Mode),
+			e.CreatedAt,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("write csv record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func readCSV(r io.Reader) ([]LeaderboardEntry, error) {
+	cr := csv.NewReader(r)
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	// Map columns by name so hand-edited files may reorder or drop "id".
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"name", "score", "level", "mode"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("csv is missing the '%s' column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var entries []LeaderboardEntry
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv line %d: %w", line, err)
+		}
+
+		score, err := strconv.Atoi(field(record, "score"))
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: invalid score: %w", line, err)
+		}
+		level, err := strconv.Atoi(field(record, "level"))
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: invalid level: %w", line, err)
+		}
+
+		entries = append(entries, LeaderboardEntry{
+			Name:      field(record, "name"),
+			Score:     score,
+			Level:     level,
+			Mode:      GameMode(field(record, "mode")),
+			CreatedAt: field(record, "created_at"),
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,93 @@
+// Package identity manages this install's signing key, used to sign the runs
+// it submits to the remote leaderboard.
+package identity
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+const pemType = "PRIVATE KEY"
+
+// DefaultPath returns where the install key lives under the XDG data
+// directory.
+func DefaultPath() (string, error) {
+	path, err := xdg.DataFile("gosnake/identity.pem")
+	if err != nil {
+		return "", fmt.Errorf("resolve identity path: %w", err)
+	}
+	return path, nil
+}
+
+// LoadOrCreate reads the ed25519 key at path, generating and saving a new one
+// the first time.
+func LoadOrCreate(path string) (ed25519.PrivateKey, error) {
+	key, err := Load(path)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create identity directory: %w", err)
+	}
+
+	// O_EXCL so two instances starting at once can't overwrite each other's
+	// key; the loser just loads the winner's.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return Load(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create key file: %w", err)
+	}
+	defer f.Close()
+
+	if err := pem.Encode(f, &pem.Block{Type: pemType, Bytes: der}); err != nil {
+		return nil, fmt.Errorf("write key file: %w", err)
+	}
+	return key, nil
+}
+
+// Load reads the ed25519 key at path.
+func Load(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != pemType {
+		return nil, fmt.Errorf("no %s block in '%s'", pemType, path)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key in '%s' is not ed25519", path)
+	}
+	return key, nil
+}
@@ -1,16 +1,36 @@
 package leaderboard
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+	"github.com/HilthonTT/gosnake/pkg/verify"
+)
 
 type SubmitScoreRequest struct {
-	PlayerName  string `json:"playerName"`
-	Score       int    `json:"score"`
-	Level       int    `json:"level"`
-	SnakeLength int    `json:"snakeLength"`
+	PlayerName  string     `json:"playerName"`
+	Score       int        `json:"score"`
+	Level       int        `json:"level"`
+	SnakeLength int        `json:"snakeLength"`
+	Mode        string     `json:"mode,omitempty"`
+	PlayedAt    *time.Time `json:"playedAt,omitempty"` // defaults to the time of submission
+
+	// Proof is the signed claim for the run, with the replay seed and input
+	// log hash; nil for runs that were not recorded.
+	Proof *verify.Proof `json:"proof,omitempty"`
+
+	// Replay is the seed and input log Proof commits to. Proof and Replay are
+	// sent together or not at all, and only once the run has been played
+	// back with verify.Verify; anyone holding them can repeat that check.
+	Replay *replay.Replay `json:"replay,omitempty"`
 }
 
 type LeaderboardEntry struct {
 	EntryID     string    `json:"entryId"`
+	PlayerName  string    `json:"playerName"`
+	Mode        string    `json:"mode"`
 	Score       int       `json:"score"`
 	Level       int       `json:"level"`
 	SnakeLength int       `json:"snakeLength"`
@@ -24,6 +44,29 @@ const (
 	EntryDeleted LeaderboardChangeType = "EntryDeleted"
 )
 
+// changeTypeOrdinals maps the API's enum ordinals to change types; the API
+// serialises enums as integers.
+var changeTypeOrdinals = []LeaderboardChangeType{EntryAdded, EntryDeleted}
+
+// UnmarshalJSON accepts either the change type's name or its enum ordinal.
+func (t *LeaderboardChangeType) UnmarshalJSON(b []byte) error {
+	var n int
+	if err := json.Unmarshal(b, &n); err == nil {
+		if n < 0 || n >= len(changeTypeOrdinals) {
+			return fmt.Errorf("unknown leaderboard change type %d", n)
+		}
+		*t = changeTypeOrdinals[n]
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*t = LeaderboardChangeType(s)
+	return nil
+}
+
 type LeaderboardChangeEvent struct {
 	ChangeType LeaderboardChangeType `json:"changeType"`
 	Entry      LeaderboardEntry      `json:"entry"`
@@ -1,45 +1,46 @@
 package leaderboard
 
 import (
-	"bufio"
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/HilthonTT/gosnake/internal/config"
 	"github.com/HilthonTT/gosnake/internal/decoder"
+	"github.com/HilthonTT/gosnake/internal/services/remote"
 )
 
-const (
-	BaseURL = "https://localhost:5001"
-)
+// ErrRejected is returned by SubmitScore when the server refuses the
+// submission itself (a validation failure), as opposed to being unreachable.
+// Retrying a rejected score will never succeed.
+var ErrRejected = errors.New("score rejected")
 
 type LeaderboardService struct {
-	client  *http.Client
+	client  *remote.Client
+	stream  *http.Client // client without a timeout, for the realtime stream
 	baseURL string
 }
 
-func NewLeaderboardService() *LeaderboardService {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true,
-		},
+// NewLeaderboardService creates a client for the remote leaderboard described
+// by cfg. Callers should check cfg.Enabled first.
+func NewLeaderboardService(cfg *config.Remote) (*LeaderboardService, error) {
+	client, err := remote.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create http client: %w", err)
 	}
 
 	return &LeaderboardService{
-		client: &http.Client{
-			Timeout:   10 * time.Second,
-			Transport: transport,
-		},
-		baseURL: BaseURL,
-	}
+		client:  client,
+		stream:  remote.StreamingClient(client.HTTPClient()),
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+	}, nil
 }
 
 func (s *LeaderboardService) GetLeaderboard(ctx context.Context, top int) ([]LeaderboardEntry, error) {
@@ -55,7 +56,7 @@ func (s *LeaderboardService) GetLeaderboard(ctx context.Context, top int) ([]Lea
 		return nil, fmt.Errorf("build request: %w", err)
 	}
 
-	resp, err := decoder.DoJSON[CollectionResponse[LeaderboardEntry]](s.client, req)
+	resp, err := remote.DoJSON[CollectionResponse[LeaderboardEntry]](s.client, req)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +81,7 @@ func (s *LeaderboardService) GetByPlayer(ctx context.Context, playerName string)
 		return []LeaderboardEntry{}, nil
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		return nil, remote.ReadError(resp)
 	}
 
 	collection, err := decoder.DecodeJSON[CollectionResponse[LeaderboardEntry]](resp.Body)
@@ -90,7 +91,9 @@ func (s *LeaderboardService) GetByPlayer(ctx context.Context, playerName string)
 	return collection.Items, nil
 }
 
-func (s *LeaderboardService) SubmitScore(ctx context.Context, req SubmitScoreRequest) (LeaderboardEntry, error) {
+// SubmitScore posts a score. A non-empty idempotencyKey is sent as the
+// Idempotency-Key header so a retried submission is recorded only once.
+func (s *LeaderboardService) SubmitScore(ctx context.Context, req SubmitScoreRequest, idempotencyKey string) (LeaderboardEntry, error) {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return LeaderboardEntry{}, fmt.Errorf("marshal request: %w", err)
@@ -106,6 +109,9 @@ func (s *LeaderboardService) SubmitScore(ctx context.Context, req SubmitScoreReq
 		return LeaderboardEntry{}, fmt.Errorf("build request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+	}
 
 	resp, err := s.client.Do(httpReq)
 	if err != nil {
@@ -113,13 +119,13 @@ func (s *LeaderboardService) SubmitScore(ctx context.Context, req SubmitScoreReq
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnprocessableEntity {
-		// ValidationProblem — surface the body so the caller can inspect it.
-		raw, _ := io.ReadAll(resp.Body)
-		return LeaderboardEntry{}, fmt.Errorf("validation error: %s", raw)
-	}
-	if resp.StatusCode != http.StatusCreated {
-		return LeaderboardEntry{}, fmt.Errorf("unexpected status %s", resp.Status)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		err := remote.ReadError(resp)
+		var httpErr *remote.HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsValidation() {
+			return LeaderboardEntry{}, fmt.Errorf("%w: %w", ErrRejected, err)
+		}
+		return LeaderboardEntry{}, err
 	}
 
 	return decoder.DecodeJSON[LeaderboardEntry](resp.Body)
@@ -145,87 +151,53 @@ func (s *LeaderboardService) DeleteEntry(ctx context.Context, entryID string) (b
 	case http.StatusNotFound:
 		return false, nil
 	default:
-		return false, fmt.Errorf("unexpected status %s", resp.Status)
+		return false, remote.ReadError(resp)
 	}
 }
 
-func (s *LeaderboardService) StreamLeaderboard(ctx context.Context, lastEventID string) (<-chan LeaderboardChangeEvent, <-chan error) {
-	events := make(chan LeaderboardChangeEvent)
-	errs := make(chan error, 1)
-
-	go func() {
-		defer close(events)
-		defer close(errs)
-
-		// Use a client without a timeout — SSE streams are long-lived.
-
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/api/v1/leaderboard/realtime", nil)
-		if err != nil {
-			errs <- fmt.Errorf("build request: %w", err)
-			return
-		}
-		req.Header.Set("Accept", "text/event-stream")
-		if lastEventID != "" {
-			req.Header.Set("Last-Event-ID", lastEventID)
-		}
-
-		resp, err := s.client.Do(req)
-		if err != nil {
-			errs <- fmt.Errorf("connect to SSE stream: %w", err)
-			return
-		}
-		defer resp.Body.Close()
+// StreamUpdate is sent by WatchLeaderboard whenever the connection state
+// changes or a change event arrives.
+type StreamUpdate struct {
+	State remote.StreamState
 
-		if resp.StatusCode != http.StatusOK {
-			errs <- fmt.Errorf("unexpected status %s", resp.Status)
-			return
-		}
+	// Change is set for change events and nil for pure state updates.
+	Change *LeaderboardChangeEvent
 
-		if err := parseSseStream(ctx, resp.Body, events); err != nil {
-			errs <- err
-		}
-	}()
-
-	return events, errs
+	// Err and RetryIn explain a remote.StreamDisconnected update.
+	Err     error
+	RetryIn time.Duration
 }
 
-func parseSseStream(ctx context.Context, r io.Reader, out chan<- LeaderboardChangeEvent) error {
-	scanner := bufio.NewScanner(r)
-
-	var dataLines []string
-
-	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		line := scanner.Text()
-
-		switch {
-		case strings.HasPrefix(line, "data:"):
-			dataLines = append(dataLines, strings.TrimPrefix(line, "data:"))
-
-		case line == "":
-			// Blank line = end of event — dispatch if we have data.
-			if len(dataLines) > 0 {
-				payload := strings.Join(dataLines, "\n")
-				dataLines = dataLines[:0]
+// WatchLeaderboard follows the realtime leaderboard stream until ctx is done,
+// reconnecting (and replaying missed events) whenever the connection drops.
+// The returned channel is closed once ctx is done.
+func (s *LeaderboardService) WatchLeaderboard(ctx context.Context) <-chan StreamUpdate {
+	updates := make(chan StreamUpdate)
+	raw := remote.WatchSSE(ctx, s.stream, s.baseURL+"/api/v1/leaderboard/realtime")
 
-				var event LeaderboardChangeEvent
-				if err := json.Unmarshal([]byte(strings.TrimSpace(payload)), &event); err != nil {
-					// Skip malformed events rather than killing the stream.
+	go func() {
+		defer close(updates)
+
+		for u := range raw {
+			update := StreamUpdate{State: u.State, Err: u.Err, RetryIn: u.RetryIn}
+			if u.Event != nil {
+				var change LeaderboardChangeEvent
+				err := json.Unmarshal([]byte(u.Event.Data), &change)
+				if err != nil || change.ChangeType == "" {
+					// Skip malformed events (and the server's retry-only
+					// preamble) rather than killing the stream.
 					continue
 				}
-				out <- event
+				update.Change = &change
 			}
-		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("read SSE stream: %w", err)
-	}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-	return nil
+	return updates
 }
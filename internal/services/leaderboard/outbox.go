@@ -0,0 +1,174 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+	"github.com/HilthonTT/gosnake/pkg/verify"
+)
+
+const (
+	outboxBatchSize = 20
+
+	// Failed submissions are retried after outboxBaseDelay, doubling on every
+	// attempt up to outboxMaxDelay.
+	outboxBaseDelay = 15 * time.Second
+	outboxMaxDelay  = 30 * time.Minute
+)
+
+// FlushResult reports what a single Outbox.Flush achieved.
+type FlushResult struct {
+	Sent     int
+	Rejected int
+	Failed   int
+	Pending  int // submissions still queued afterwards
+}
+
+// Outbox queues score submissions in SQLite and delivers them to the remote
+// leaderboard, retrying with exponential backoff until the server accepts
+// them. Every queued score carries an idempotency key, so a retry after a lost
+// response cannot post the same run twice.
+type Outbox struct {
+	repo    *data.OutboxRepository
+	service *LeaderboardService
+
+	// mu serialises flushes so the background sync and a game-over flush
+	// never send the same row concurrently.
+	mu sync.Mutex
+}
+
+func NewOutbox(repo *data.OutboxRepository, service *LeaderboardService) *Outbox {
+	return &Outbox{repo: repo, service: service}
+}
+
+// Enqueue stores a local leaderboard row for submission. It does not contact
+// the server; call Flush afterwards.
+func (o *Outbox) Enqueue(e data.LeaderboardEntry) error {
+	_, err := o.repo.Enqueue(data.PendingScore{
+		EntryID:     e.ID,
+		Name:        e.Name,
+		Score:       e.Score,
+		Level:       e.Level,
+		SnakeLength: e.SnakeLength,
+		Mode:        e.Mode,
+		PlayedAt:    e.CreatedAt,
+	})
+	return err
+}
+
+// Flush submits every queued score that is due. Network and server errors
+// reschedule the score; a validation rejection drops it and marks its local
+// row, since resending the same payload can never succeed. Signed runs are
+// played back with verify.Verify first, and one that does not reproduce its
+// claimed result is rejected without being sent.
+func (o *Outbox) Flush(ctx context.Context) (FlushResult, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var res FlushResult
+
+	due, err := o.repo.Due(time.Now(), outboxBatchSize)
+	if err != nil {
+		return res, err
+	}
+
+	for _, p := range due {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		var entry LeaderboardEntry
+		req, err := submitRequest(p)
+		if err == nil {
+			entry, err = o.service.SubmitScore(ctx, req, p.IdempotencyKey)
+		}
+		switch {
+		case err == nil:
+			res.Sent++
+			if err := o.repo.Complete(p, entry.EntryID); err != nil {
+				return res, err
+			}
+
+		case errors.Is(err, ErrRejected):
+			res.Rejected++
+			log.Printf("dropping rejected score %d for %s: %v", p.Score, p.Name, err)
+			if err := o.repo.Reject(p); err != nil {
+				return res, err
+			}
+
+		default:
+			res.Failed++
+			attempts := p.Attempts + 1
+			next := time.Now().Add(backoff(attempts))
+			if err := o.repo.Reschedule(p.ID, attempts, next, err.Error()); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	res.Pending, err = o.repo.Count()
+	return res, err
+}
+
+// submitRequest builds the submission for p. A run with a proof and its
+// replay is verified; an unreadable proof or replay is dropped with a log line
+// and the run is sent unsigned, as runs played before signing were.
+func submitRequest(p data.PendingScore) (SubmitScoreRequest, error) {
+	req := SubmitScoreRequest{
+		PlayerName: p.Name,
+		Score:      p.Score,
+		Level:      p.Level,
+		// Rows imported or played before snake lengths were recorded have
+		// none; the server requires at least 1.
+		SnakeLength: max(p.SnakeLength, 1),
+		Mode:        string(p.Mode),
+	}
+	if playedAt, err := data.ParseTimestamp(p.PlayedAt); err == nil {
+		req.PlayedAt = &playedAt
+	}
+	if p.Proof != "" {
+		var proof verify.Proof
+		if err := json.Unmarshal([]byte(p.Proof), &proof); err != nil {
+			log.Printf("dropping unreadable proof of entry %d: %v", p.EntryID, err)
+		} else {
+			req.Proof = &proof
+		}
+	}
+	if req.Proof != nil && p.Replay != "" {
+		var rep replay.Replay
+		if err := json.Unmarshal([]byte(p.Replay), &rep); err != nil {
+			log.Printf("dropping unreadable replay of entry %d: %v", p.EntryID, err)
+		} else {
+			req.Replay = &rep
+		}
+	}
+
+	// A proof means nothing without the replay to check it against.
+	if req.Proof == nil || req.Replay == nil {
+		req.Proof, req.Replay = nil, nil
+		return req, nil
+	}
+	if _, err := verify.Verify(verify.Run{Proof: *req.Proof, Replay: *req.Replay}); err != nil {
+		return req, fmt.Errorf("%w: run does not verify: %w", ErrRejected, err)
+	}
+	return req, nil
+}
+
+// backoff returns the delay before retry number attempts, with ±20% jitter so
+// several clients coming back online don't retry in lockstep.
+func backoff(attempts int) time.Duration {
+	d := outboxMaxDelay
+	if shift := attempts - 1; shift < 16 {
+		d = min(outboxBaseDelay<<shift, outboxMaxDelay)
+	}
+	jitter := time.Duration((rand.Float64()*0.4 - 0.2) * float64(d))
+	return d + jitter
+}
@@ -0,0 +1,422 @@
+package leaderboard
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/config"
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+	"github.com/HilthonTT/gosnake/pkg/verify"
+)
+
+// fakeAPI stands in for the remote leaderboard API. It serves remote as the
+// leaderboard and answers every submission with status.
+type fakeAPI struct {
+	mu     sync.Mutex
+	status int
+	remote []LeaderboardEntry
+	posted []SubmitScoreRequest
+	keys   []string // Idempotency-Key of every submission
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/leaderboard":
+		_ = json.NewEncoder(w).Encode(CollectionResponse[LeaderboardEntry]{Items: f.remote})
+
+	case r.Method == http.MethodGet:
+		http.NotFound(w, r)
+
+	case r.Method == http.MethodPost:
+		var req SubmitScoreRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.posted = append(f.posted, req)
+		f.keys = append(f.keys, r.Header.Get("Idempotency-Key"))
+
+		switch f.status {
+		case http.StatusCreated:
+			entry := LeaderboardEntry{
+				EntryID:    fmt.Sprintf("remote-%d", len(f.posted)),
+				PlayerName: req.PlayerName,
+				Mode:       req.Mode,
+				Score:      req.Score,
+				Level:      req.Level,
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(entry)
+		case http.StatusBadRequest:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"title":  "One or more validation errors occurred.",
+				"status": http.StatusBadRequest,
+				"errors": map[string][]string{"score": {"Score is not plausible."}},
+			})
+		default:
+			w.WriteHeader(f.status)
+		}
+	}
+}
+
+func (f *fakeAPI) submissions() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.posted)
+}
+
+type syncFixture struct {
+	api    *fakeAPI
+	repo   *data.LeaderboardRepository
+	queue  *data.OutboxRepository
+	outbox *Outbox
+	syncer *Syncer
+}
+
+func newSyncFixture(t *testing.T, status int) *syncFixture {
+	t.Helper()
+
+	api := &fakeAPI{status: status}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	service, err := NewLeaderboardService(&config.Remote{
+		Enabled: true,
+		BaseURL: srv.URL,
+		Timeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "gosnake.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	f := &syncFixture{
+		api:   api,
+		repo:  data.NewLeaderboardRepository(db),
+		queue: data.NewOutboxRepository(db),
+	}
+	f.outbox = NewOutbox(f.queue, service)
+	f.syncer = NewSyncer(f.repo, f.outbox, service)
+	return f
+}
+
+// save stores a local run and returns it as read back from the database.
+func (f *syncFixture) save(t *testing.T, name string, score int) data.LeaderboardEntry {
+	t.Helper()
+
+	id, err := f.repo.SaveEntry(data.LeaderboardEntry{Name: name, Score: score, Level: 1, Mode: data.GameModeNormal, SnakeLength: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := f.repo.Find(data.LeaderboardFilter{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	t.Fatalf("saved entry %d not found", id)
+	return data.LeaderboardEntry{}
+}
+
+func (f *syncFixture) unsynced(t *testing.T) int {
+	t.Helper()
+
+	n, err := f.repo.CountUnsynced()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestOutboxFlush(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+
+		want         FlushResult
+		wantUnsynced int
+		wantRetry    bool // the submission stays queued for a later attempt
+	}{
+		{name: "accepted", status: http.StatusCreated, want: FlushResult{Sent: 1}},
+		{name: "rejected", status: http.StatusBadRequest, want: FlushResult{Rejected: 1}},
+		{name: "server error", status: http.StatusServiceUnavailable, want: FlushResult{Failed: 1, Pending: 1},
+			wantUnsynced: 1, wantRetry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSyncFixture(t, tt.status)
+			entry := f.save(t, "ada", 120)
+			if err := f.outbox.Enqueue(entry); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := f.outbox.Flush(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != tt.want {
+				t.Errorf("Flush() = %+v, want %+v", res, tt.want)
+			}
+			if got := f.unsynced(t); got != tt.wantUnsynced {
+				t.Errorf("%d entries unsynced, want %d", got, tt.wantUnsynced)
+			}
+
+			due, err := f.queue.Due(time.Now().Add(time.Hour), outboxBatchSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(due) == 1; got != tt.wantRetry {
+				t.Fatalf("submission queued for retry = %t, want %t", got, tt.wantRetry)
+			}
+			if tt.wantRetry {
+				if due[0].Attempts != 1 || !due[0].NextAttemptAt.After(time.Now()) {
+					t.Errorf("retry after %d attempts at %v, want 1 attempt in the future", due[0].Attempts, due[0].NextAttemptAt)
+				}
+				if due[0].LastError == "" {
+					t.Error("failed submission has no last error")
+				}
+			}
+		})
+	}
+}
+
+func TestOutboxRetryKeepsIdempotencyKey(t *testing.T) {
+	f := newSyncFixture(t, http.StatusServiceUnavailable)
+	entry := f.save(t, "ada", 120)
+	if err := f.outbox.Enqueue(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.outbox.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Make the retry due now and let the server recover.
+	due, err := f.queue.Due(time.Now().Add(time.Hour), outboxBatchSize)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("Due() = %v, %v; want the failed submission", due, err)
+	}
+	if err := f.queue.Reschedule(due[0].ID, due[0].Attempts, time.Now().Add(-time.Second), due[0].LastError); err != nil {
+		t.Fatal(err)
+	}
+	f.api.mu.Lock()
+	f.api.status = http.StatusCreated
+	f.api.mu.Unlock()
+
+	res, err := f.outbox.Flush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Sent != 1 || res.Pending != 0 {
+		t.Fatalf("retry Flush() = %+v, want one sent and none pending", res)
+	}
+
+	f.api.mu.Lock()
+	keys := f.api.keys
+	f.api.mu.Unlock()
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("idempotency keys = %q, want the same key on both attempts", keys)
+	}
+
+	if _, ok, err := f.repo.FindByRemoteID("remote-2"); err != nil || !ok {
+		t.Errorf("accepted entry not linked to its remote id: ok=%t, err=%v", ok, err)
+	}
+}
+
+func TestSyncerSync(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+
+		// alsoRemote puts the local run on the server already, as if it was
+		// submitted before its response got lost.
+		alsoRemote bool
+
+		want       SyncResult
+		wantPosted int
+	}{
+		{name: "pushes local runs", status: http.StatusCreated,
+			want: SyncResult{Pulled: 1, Pushed: 1}, wantPosted: 1},
+		{name: "links instead of resubmitting", status: http.StatusCreated, alsoRemote: true,
+			want: SyncResult{Pulled: 2}},
+		{name: "rejected runs are not resubmitted", status: http.StatusBadRequest,
+			want: SyncResult{Pulled: 1}, wantPosted: 1},
+		{name: "failed runs wait for their backoff", status: http.StatusServiceUnavailable,
+			want: SyncResult{Pulled: 1, Pending: 1}, wantPosted: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSyncFixture(t, tt.status)
+			f.api.remote = []LeaderboardEntry{{
+				EntryID: "other", PlayerName: "grace", Mode: "normal", Score: 300, Level: 2, SnakeLength: 8,
+				PlayedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			}}
+
+			local := f.save(t, "ada", 120)
+			if tt.alsoRemote {
+				playedAt, err := data.ParseTimestamp(local.CreatedAt)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.api.remote = append(f.api.remote, LeaderboardEntry{
+					EntryID: "mine", PlayerName: local.Name, Mode: string(local.Mode),
+					Score: local.Score, Level: local.Level, SnakeLength: local.SnakeLength, PlayedAt: playedAt,
+				})
+			}
+
+			res, err := f.syncer.Sync(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != tt.want {
+				t.Errorf("Sync() = %+v, want %+v", res, tt.want)
+			}
+			if got := f.api.submissions(); got != tt.wantPosted {
+				t.Errorf("%d submissions, want %d", got, tt.wantPosted)
+			}
+
+			// Nothing is pulled twice, and nothing settled is sent again.
+			again, err := f.syncer.Sync(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again.Pulled != 0 || again.Pushed != 0 {
+				t.Errorf("second Sync() = %+v, want nothing pulled or pushed", again)
+			}
+			if got := f.api.submissions(); got != tt.wantPosted {
+				t.Errorf("%d submissions after a second sync, want %d", got, tt.wantPosted)
+			}
+		})
+	}
+}
+
+// signedRun plays a normal game without steering and returns its proof and
+// replay as stored with the run, with the proof claiming score.
+func signedRun(t *testing.T, score func(played int) int) (proof, rep string) {
+	t.Helper()
+
+	g, err := replay.NewGame(snake.GameModeNormal, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := replay.NewRecorder(snake.GameModeNormal, 7)
+	for !g.IsGameOver() {
+		g.Tick()
+		rec.Tick()
+	}
+
+	r := rec.Replay()
+	res := replay.Result{Score: score(g.Score()), Level: g.Level(), SnakeLength: g.SnakeLength(), Ticks: r.Ticks, GameOver: true}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := json.Marshal(verify.Sign(verify.NewClaim("ada", r, res), key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(p), string(b)
+}
+
+func honest(played int) int { return played }
+func forged(played int) int { return played + 1000 }
+
+func TestSubmitRequestProof(t *testing.T) {
+	proof, rep := signedRun(t, honest)
+	forgedProof, _ := signedRun(t, forged)
+
+	tests := []struct {
+		name      string
+		proof     string
+		replay    string
+		wantSent  bool // proof and replay go out with the score
+		wantError bool
+	}{
+		{name: "unsigned"},
+		{name: "signed", proof: proof, replay: rep, wantSent: true},
+		{name: "signed before replays were kept", proof: proof},
+		{name: "replay without proof", replay: rep},
+		{name: "unreadable proof", proof: "{", replay: rep},
+		{name: "unreadable replay", proof: proof, replay: "{"},
+		{name: "forged score", proof: forgedProof, replay: rep, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := submitRequest(data.PendingScore{
+				EntryID: 1, Name: "ada", Score: 120, Level: 1, Mode: data.GameModeNormal,
+				Proof: tt.proof, Replay: tt.replay,
+			})
+			if tt.wantError {
+				if !errors.Is(err, ErrRejected) {
+					t.Fatalf("submitRequest() error = %v, want a rejection", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sent := req.Proof != nil; sent != tt.wantSent || (req.Replay != nil) != tt.wantSent {
+				t.Errorf("proof sent = %t, replay sent = %t; want %t", sent, req.Replay != nil, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestOutboxFlushForgedRun(t *testing.T) {
+	f := newSyncFixture(t, http.StatusCreated)
+	entry := f.save(t, "ada", 120)
+	proof, rep := signedRun(t, forged)
+	if err := f.repo.SaveProof(entry.ID, proof, rep); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.outbox.Enqueue(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := f.outbox.Flush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != (FlushResult{Rejected: 1}) {
+		t.Errorf("Flush() = %+v, want the forged run rejected", res)
+	}
+	if got := f.api.submissions(); got != 0 {
+		t.Errorf("forged run was submitted %d times", got)
+	}
+	if got := f.unsynced(t); got != 0 {
+		t.Errorf("%d entries unsynced, want the forged run settled", got)
+	}
+}
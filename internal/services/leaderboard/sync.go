@@ -0,0 +1,203 @@
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+)
+
+// syncPullSize is how many of the remote leaderboard's top entries a sync
+// mirrors locally.
+const syncPullSize = 500
+
+// SyncResult reports what a single Syncer.Sync achieved.
+type SyncResult struct {
+	Pulled  int // remote entries inserted or linked locally
+	Pushed  int // local entries accepted by the server
+	Pending int // local entries still not on the server
+}
+
+// Syncer keeps the local SQLite leaderboard and the remote leaderboard in step.
+// Remote entries are mirrored locally with OriginRemote; local entries are
+// pushed through the Outbox, and once accepted both sides share the remote
+// entry ID so neither is ever copied twice.
+type Syncer struct {
+	local   *data.LeaderboardRepository
+	outbox  *Outbox
+	service *LeaderboardService
+
+	// mu serialises local writes: merges, and queueing unsynced entries so
+	// two syncs never enqueue the same row twice. It is never held across a
+	// request.
+	mu sync.Mutex
+}
+
+func NewSyncer(local *data.LeaderboardRepository, outbox *Outbox, service *LeaderboardService) *Syncer {
+	return &Syncer{local: local, outbox: outbox, service: service}
+}
+
+// Pending returns the number of local entries not yet on the server.
+func (s *Syncer) Pending() (int, error) {
+	return s.local.CountUnsynced()
+}
+
+// Sync pulls the remote top entries, links or inserts them locally, then
+// queues every local-only entry and flushes the outbox. Pulling first matters:
+// an entry pushed from another machine (or whose response was lost) is linked
+// rather than posted again. The lock is only held between requests, so Apply
+// never waits on the network.
+func (s *Syncer) Sync(ctx context.Context) (SyncResult, error) {
+	var res SyncResult
+
+	top, err := s.service.GetLeaderboard(ctx, syncPullSize)
+	if err != nil {
+		return res, fmt.Errorf("pull leaderboard: %w", err)
+	}
+	if err := s.pull(top, &res); err != nil {
+		return res, err
+	}
+
+	// Entries outside the remote top list can only be found per player.
+	names, err := s.unqueuedPlayers()
+	if err != nil {
+		return res, err
+	}
+	for _, name := range names {
+		entries, err := s.service.GetByPlayer(ctx, name)
+		if err != nil {
+			return res, fmt.Errorf("pull scores for %s: %w", name, err)
+		}
+		if err := s.pull(entries, &res); err != nil {
+			return res, err
+		}
+	}
+
+	if err := s.enqueueUnsynced(); err != nil {
+		return res, err
+	}
+
+	flushed, err := s.outbox.Flush(ctx)
+	res.Pushed = flushed.Sent
+	if err != nil {
+		return res, err
+	}
+
+	res.Pending, err = s.local.CountUnsynced()
+	return res, err
+}
+
+// pull merges entries fetched from the server.
+func (s *Syncer) pull(remote []LeaderboardEntry, res *SyncResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.merge(remote, res)
+}
+
+// unqueuedPlayers returns the players with unsynced entries that are not in
+// the outbox yet.
+func (s *Syncer) unqueuedPlayers() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unsynced, err := s.local.Unsynced()
+	if err != nil {
+		return nil, err
+	}
+	queued, err := s.outbox.repo.PendingEntryIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, e := range unsynced {
+		if !queued[e.ID] && !seen[e.Name] {
+			seen[e.Name] = true
+			names = append(names, e.Name)
+		}
+	}
+	return names, nil
+}
+
+// enqueueUnsynced queues every unsynced entry not already in the outbox.
+func (s *Syncer) enqueueUnsynced() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unsynced, err := s.local.Unsynced()
+	if err != nil {
+		return err
+	}
+	queued, err := s.outbox.repo.PendingEntryIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range unsynced {
+		if queued[e.ID] {
+			continue
+		}
+		if err := s.outbox.Enqueue(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch follows the remote leaderboard's realtime stream until ctx is done.
+// Pass each change to Apply to mirror it locally.
+func (s *Syncer) Watch(ctx context.Context) <-chan StreamUpdate {
+	return s.service.WatchLeaderboard(ctx)
+}
+
+// Apply mirrors a realtime change into the local leaderboard and returns the
+// affected local row: the inserted or linked row for EntryAdded, the removed
+// one for EntryDeleted. ok is false when nothing local changed.
+func (s *Syncer) Apply(change LeaderboardChangeEvent) (entry data.LeaderboardEntry, ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch change.ChangeType {
+	case EntryAdded:
+		var res SyncResult
+		if err := s.merge([]LeaderboardEntry{change.Entry}, &res); err != nil || res.Pulled == 0 {
+			return entry, false, err
+		}
+		return s.local.FindByRemoteID(change.Entry.EntryID)
+
+	case EntryDeleted:
+		entry, found, err := s.local.FindByRemoteID(change.Entry.EntryID)
+		if err != nil || !found {
+			return entry, false, err
+		}
+		deleted, err := s.local.DeleteRemote(change.Entry.EntryID)
+		return entry, deleted, err
+	}
+
+	return entry, false, nil
+}
+
+func (s *Syncer) merge(remote []LeaderboardEntry, res *SyncResult) error {
+	entries := make([]data.LeaderboardEntry, len(remote))
+	for i, r := range remote {
+		entries[i] = data.LeaderboardEntry{
+			Name:        r.PlayerName,
+			Score:       r.Score,
+			Level:       r.Level,
+			Mode:        data.GameMode(r.Mode),
+			CreatedAt:   r.PlayedAt.UTC().Format(time.RFC3339),
+			SnakeLength: r.SnakeLength,
+			RemoteID:    r.EntryID,
+		}
+	}
+
+	merged, err := s.local.MergeRemote(entries)
+	if err != nil {
+		return err
+	}
+	res.Pulled += merged.Inserted + merged.Linked
+	return nil
+}
@@ -0,0 +1,114 @@
+package remote
+
+import (
+	"sync"
+	"time"
+)
+
+// Breaker is a circuit breaker for one server. After threshold consecutive
+// failures it opens and fails requests immediately for cooldown, then lets a
+// single trial request through; its outcome closes or re-opens the circuit.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial request is in flight
+}
+
+// NewBreaker returns a closed breaker. A threshold below 1 disables it.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a request may be sent now.
+func (b *Breaker) Allow() bool {
+	if b.threshold < 1 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// Success records a request that reached a healthy server.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+}
+
+// Failure records a request that failed because of the server or network.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// Release ends a request whose outcome says nothing about the server, such as
+// one its caller cancelled. A half-open trial is given back so the next
+// request can try again.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// Open reports whether the breaker is currently failing requests fast.
+func (b *Breaker) Open() bool {
+	if b.threshold < 1 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold && time.Since(b.openedAt) < b.cooldown
+}
+
+// breakerKey identifies a shared breaker: clients only share one when they
+// talk to the same server with the same breaker settings.
+type breakerKey struct {
+	baseURL   string
+	threshold int
+	cooldown  time.Duration
+}
+
+var (
+	breakersMu sync.Mutex
+	breakers   = map[breakerKey]*Breaker{}
+)
+
+// breakerFor returns the breaker shared by every client of baseURL with the
+// same settings, so the leaderboard and tips services back off from a dead
+// server together.
+func breakerFor(baseURL string, threshold int, cooldown time.Duration) *Breaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	key := breakerKey{baseURL, threshold, cooldown}
+	b, ok := breakers[key]
+	if !ok {
+		b = NewBreaker(threshold, cooldown)
+		breakers[key] = b
+	}
+	return b
+}
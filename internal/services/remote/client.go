@@ -0,0 +1,156 @@
+package remote
+
+import (
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/config"
+)
+
+const (
+	retryBaseDelay = 250 * time.Millisecond
+	retryMaxDelay  = 4 * time.Second
+)
+
+// Client sends requests to the remote API, retrying server and network
+// failures with jittered backoff and failing fast while the server's circuit
+// breaker is open. Every attempt is logged at debug level.
+type Client struct {
+	http    *http.Client
+	retries int
+	breaker *Breaker
+}
+
+// NewClient builds a Client for the API described by cfg.
+func NewClient(cfg *config.Remote) (*Client, error) {
+	hc, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	return &Client{
+		http:    hc,
+		retries: cfg.Retries,
+		breaker: breakerFor(baseURL, cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}, nil
+}
+
+// HTTPClient returns the underlying client, without retries or breaker.
+func (c *Client) HTTPClient() *http.Client {
+	return c.http
+}
+
+// Do sends req like http.Client.Do. Network errors and 5xx responses are
+// retried when the request is safe to repeat: it has a replayable body and is
+// either idempotent by method or carries an Idempotency-Key header. The last
+// response is returned whatever its status; ErrCircuitOpen is returned without
+// sending anything while the server is considered down.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	url := req.URL.Redacted()
+
+	if !c.breaker.Allow() {
+		slog.DebugContext(ctx, "http request skipped", "method", req.Method, "url", url, "reason", "circuit open")
+		return nil, ErrCircuitOpen
+	}
+
+	retryable := canRetry(req)
+
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 {
+			r = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					c.breaker.Release()
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		start := time.Now()
+		slog.DebugContext(ctx, "http request", "method", r.Method, "url", url, "attempt", attempt)
+
+		resp, err := c.http.Do(r)
+		if err != nil && ctx.Err() != nil {
+			// The caller gave up; that says nothing about the server.
+			c.breaker.Release()
+			return nil, err
+		}
+
+		failed := err != nil || resp.StatusCode >= 500
+		if !failed {
+			c.breaker.Success()
+			slog.DebugContext(ctx, "http response",
+				"method", r.Method, "url", url, "status", resp.StatusCode,
+				"duration", time.Since(start), "attempt", attempt)
+			return resp, nil
+		}
+
+		c.breaker.Failure()
+		if err != nil {
+			slog.DebugContext(ctx, "http request failed",
+				"method", r.Method, "url", url, "err", err,
+				"duration", time.Since(start), "attempt", attempt)
+		} else {
+			slog.DebugContext(ctx, "http response",
+				"method", r.Method, "url", url, "status", resp.StatusCode,
+				"duration", time.Since(start), "attempt", attempt)
+		}
+
+		if c.breaker.Open() {
+			slog.WarnContext(ctx, "remote server unavailable, pausing requests", "url", url)
+			return resp, err
+		}
+		if !retryable || attempt > c.retries {
+			return resp, err
+		}
+
+		if resp != nil {
+			// Drain so the connection can be reused.
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+			resp.Body.Close()
+		}
+
+		delay := backoff(attempt)
+		slog.DebugContext(ctx, "http retry", "method", r.Method, "url", url, "in", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			c.breaker.Release()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// canRetry reports whether sending req twice is harmless.
+func canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return req.Header.Get("Idempotency-Key") != ""
+	}
+}
+
+// backoff returns the delay before retry number attempt: exponential from
+// retryBaseDelay, capped at retryMaxDelay, jittered into its upper half so
+// clients that failed together don't retry together.
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << (attempt - 1)
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	return d/2 + rand.N(d/2+1)
+}
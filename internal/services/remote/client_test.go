@@ -0,0 +1,106 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBreakerTrial(t *testing.T) {
+	const cooldown = 10 * time.Millisecond
+
+	tests := []struct {
+		name      string
+		settle    func(b *Breaker)
+		wantAllow bool
+	}{
+		{name: "success closes", settle: (*Breaker).Success, wantAllow: true},
+		{name: "failure reopens", settle: (*Breaker).Failure, wantAllow: false},
+		{name: "release retries", settle: (*Breaker).Release, wantAllow: true},
+		{name: "unsettled trial", settle: func(*Breaker) {}, wantAllow: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(1, cooldown)
+			b.Failure()
+			if b.Allow() {
+				t.Fatal("open breaker allowed a request")
+			}
+
+			time.Sleep(2 * cooldown)
+			if !b.Allow() {
+				t.Fatal("breaker refused the half-open trial")
+			}
+			tt.settle(b)
+
+			if got := b.Allow(); got != tt.wantAllow {
+				t.Errorf("Allow() after trial = %t, want %t", got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestClientCancelledTrial(t *testing.T) {
+	const cooldown = 10 * time.Millisecond
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := &Client{http: srv.Client(), breaker: NewBreaker(1, cooldown)}
+	c.breaker.Failure()
+	time.Sleep(2 * cooldown)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Do(req); err == nil {
+		t.Fatal("Do succeeded after its context expired")
+	}
+	if !c.breaker.Allow() {
+		t.Error("a cancelled trial left the breaker stuck half-open")
+	}
+}
+
+func TestBreakerFor(t *testing.T) {
+	const url = "https://breaker-for.test"
+
+	shared := breakerFor(url, 3, time.Second)
+	tests := []struct {
+		name      string
+		url       string
+		threshold int
+		cooldown  time.Duration
+		wantSame  bool
+	}{
+		{name: "same settings", url: url, threshold: 3, cooldown: time.Second, wantSame: true},
+		{name: "other server", url: url + "/other", threshold: 3, cooldown: time.Second},
+		{name: "other threshold", url: url, threshold: 5, cooldown: time.Second},
+		{name: "other cooldown", url: url, threshold: 3, cooldown: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := breakerFor(tt.url, tt.threshold, tt.cooldown)
+			if got := b == shared; got != tt.wantSame {
+				t.Errorf("shares the breaker = %t, want %t", got, tt.wantSame)
+			}
+			if b.threshold != tt.threshold || b.cooldown != tt.cooldown {
+				t.Errorf("breaker has threshold %d and cooldown %v, want %d and %v", b.threshold, b.cooldown, tt.threshold, tt.cooldown)
+			}
+		})
+	}
+}
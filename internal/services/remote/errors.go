@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// maxErrorBody caps how much of an error response is read.
+const maxErrorBody = 64 << 10
+
+// ErrCircuitOpen is returned without contacting the server while the circuit
+// breaker for that server is open.
+var ErrCircuitOpen = errors.New("server unavailable (circuit open)")
+
+// ProblemDetails is an RFC 7807 error body, as produced by ASP.NET's
+// Results.Problem and Results.ValidationProblem.
+type ProblemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+
+	// Errors holds per-field messages for a ValidationProblem.
+	Errors map[string][]string `json:"errors"`
+}
+
+// HTTPError is returned for responses with an unexpected status.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+
+	// Problem is the parsed error body, or nil if the body was not a
+	// ProblemDetails document.
+	Problem *ProblemDetails
+
+	// Body is the raw error body when it could not be parsed.
+	Body string
+}
+
+func (e *HTTPError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("unexpected status ")
+	sb.WriteString(e.Status)
+
+	switch p := e.Problem; {
+	case p != nil:
+		msg := p.Detail
+		if msg == "" {
+			msg = p.Title
+		}
+		if msg != "" {
+			sb.WriteString(": ")
+			sb.WriteString(msg)
+		}
+		if len(p.Errors) > 0 {
+			var fields []string
+			for _, field := range slices.Sorted(maps.Keys(p.Errors)) {
+				fields = append(fields, fmt.Sprintf("%s: %s", field, strings.Join(p.Errors[field], ", ")))
+			}
+			sb.WriteString(" (")
+			sb.WriteString(strings.Join(fields, "; "))
+			sb.WriteString(")")
+		}
+	case e.Body != "":
+		sb.WriteString(": ")
+		sb.WriteString(e.Body)
+	}
+
+	return sb.String()
+}
+
+// IsValidation reports whether the server rejected the request itself, so
+// sending it again unchanged can never succeed.
+func (e *HTTPError) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// Temporary reports whether the request may succeed if retried later.
+func (e *HTTPError) Temporary() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// ReadError builds an HTTPError from resp, consuming its body. The caller
+// still closes the body.
+func ReadError(resp *http.Response) error {
+	e := &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	var p ProblemDetails
+	if err := json.Unmarshal(raw, &p); err == nil && (p.Title != "" || p.Detail != "" || len(p.Errors) > 0) {
+		e.Problem = &p
+	} else {
+		e.Body = strings.TrimSpace(string(raw))
+	}
+
+	return e
+}
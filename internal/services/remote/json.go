@@ -0,0 +1,30 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/HilthonTT/gosnake/internal/decoder"
+)
+
+// Doer sends HTTP requests; both *http.Client and *Client satisfy it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoJSON sends req and decodes a 200 response into T. Any other status is
+// returned as an *HTTPError.
+func DoJSON[T any](client Doer, req *http.Request) (T, error) {
+	var zero T
+	resp, err := client.Do(req)
+	if err != nil {
+		return zero, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return zero, ReadError(resp)
+	}
+
+	return decoder.DecodeJSON[T](resp.Body)
+}
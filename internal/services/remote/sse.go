@@ -0,0 +1,222 @@
+package remote
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSSERetry is how long to wait before reconnecting to an event stream
+// until the server says otherwise with a retry: field.
+const DefaultSSERetry = 3 * time.Second
+
+// minSSERetry bounds a server's retry: field from below, so a retry of 0
+// can't turn a failing stream into a tight reconnect loop.
+const minSSERetry = time.Second
+
+// SSEEvent is one event dispatched from a text/event-stream body.
+type SSEEvent struct {
+	// ID is the stream's last event ID at dispatch time: the value to send
+	// as Last-Event-ID when reconnecting.
+	ID   string
+	Type string // "message" unless the server set an event: field
+	Data string
+}
+
+// StreamingClient returns a copy of client without its overall request
+// timeout, for long-lived event streams. Cancel the request context instead.
+func StreamingClient(client *http.Client) *http.Client {
+	c := *client
+	c.Timeout = 0
+	return &c
+}
+
+// ParseSSE reads a text/event-stream body until it ends or ctx is done,
+// calling onEvent for every dispatched event. onRetry, if non-nil, is called
+// whenever the server sets a new reconnection delay. lastEventID seeds the
+// ID reported on events, so it survives reconnects.
+func ParseSSE(ctx context.Context, r io.Reader, lastEventID string, onEvent func(SSEEvent), onRetry func(time.Duration)) error {
+	scanner := bufio.NewScanner(r)
+
+	var (
+		eventType string
+		dataLines []string
+		hasData   bool
+	)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line := scanner.Text()
+		if line == "" {
+			// Blank line = end of event — dispatch if we have data.
+			if hasData {
+				if eventType == "" {
+					eventType = "message"
+				}
+				onEvent(SSEEvent{
+					ID:   lastEventID,
+					Type: eventType,
+					Data: strings.Join(dataLines, "\n"),
+				})
+			}
+			eventType, dataLines, hasData = "", dataLines[:0], false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // comment, used as a keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "data":
+			dataLines = append(dataLines, value)
+			hasData = true
+		case "event":
+			eventType = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 && onRetry != nil {
+				onRetry(time.Duration(ms) * time.Millisecond)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read SSE stream: %w", err)
+	}
+	return nil
+}
+
+// StreamState describes a long-lived event stream connection.
+type StreamState int
+
+const (
+	StreamConnecting StreamState = iota
+	StreamLive
+	StreamDisconnected
+)
+
+// StreamUpdate is sent by WatchSSE whenever the connection state changes or
+// an event arrives.
+type StreamUpdate struct {
+	State StreamState
+
+	// Event is set for dispatched events and nil for pure state updates.
+	Event *SSEEvent
+
+	// Err and RetryIn explain a StreamDisconnected update.
+	Err     error
+	RetryIn time.Duration
+}
+
+// WatchSSE follows the event stream at url until ctx is done, reconnecting
+// after the server's retry interval whenever the connection drops.
+// Reconnects send Last-Event-ID so the server can replay missed events. The
+// returned channel is closed once ctx is done. client should have no overall
+// timeout; see StreamingClient.
+func WatchSSE(ctx context.Context, client *http.Client, url string) <-chan StreamUpdate {
+	updates := make(chan StreamUpdate)
+
+	send := func(u StreamUpdate) bool {
+		select {
+		case updates <- u:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(updates)
+
+		var lastEventID string
+		retry := DefaultSSERetry
+
+		for {
+			if !send(StreamUpdate{State: StreamConnecting}) {
+				return
+			}
+
+			err := streamOnce(ctx, client, url, lastEventID, func(u StreamUpdate) bool {
+				if u.Event != nil {
+					lastEventID = u.Event.ID
+				}
+				return send(u)
+			}, func(d time.Duration) {
+				retry = max(d, minSSERetry)
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				err = errors.New("stream closed by server")
+			}
+
+			if !send(StreamUpdate{State: StreamDisconnected, Err: err, RetryIn: retry}) {
+				return
+			}
+			select {
+			case <-time.After(retry):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates
+}
+
+// streamOnce holds a single connection to the stream until it ends.
+func streamOnce(
+	ctx context.Context,
+	client *http.Client,
+	url, lastEventID string,
+	emit func(StreamUpdate) bool,
+	onRetry func(time.Duration),
+) error {
+	// Cancelling stops ParseSSE as soon as emit reports the watcher is gone.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("connect to SSE stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if !emit(StreamUpdate{State: StreamLive}) {
+		return nil
+	}
+
+	return ParseSSE(ctx, resp.Body, lastEventID, func(e SSEEvent) {
+		if !emit(StreamUpdate{State: StreamLive, Event: &e}) {
+			cancel()
+		}
+	}, onRetry)
+}
@@ -0,0 +1,49 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWatchSSERetry(t *testing.T) {
+	tests := []struct {
+		name  string
+		retry string // retry: field sent by the server, if any
+		want  time.Duration
+	}{
+		{name: "default", want: DefaultSSERetry},
+		{name: "server interval", retry: "5000", want: 5 * time.Second},
+		{name: "zero", retry: "0", want: minSSERetry},
+		{name: "too short", retry: "10", want: minSSERetry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				if tt.retry != "" {
+					fmt.Fprintf(w, "retry: %s\n\n", tt.retry)
+				}
+			}))
+			defer srv.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			for u := range WatchSSE(ctx, srv.Client(), srv.URL) {
+				if u.State != StreamDisconnected {
+					continue
+				}
+				if u.RetryIn != tt.want {
+					t.Errorf("reconnecting in %v, want %v", u.RetryIn, tt.want)
+				}
+				return
+			}
+			t.Fatal("stream never reported the disconnect")
+		})
+	}
+}
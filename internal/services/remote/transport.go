@@ -0,0 +1,103 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/HilthonTT/gosnake/internal/config"
+)
+
+// NewHTTPClient builds the HTTP client shared by every remote service. TLS
+// verification stays on unless cfg explicitly disables it.
+func NewHTTPClient(cfg *config.Remote) (*http.Client, error) {
+	tlsCfg, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+	}, nil
+}
+
+func tlsConfig(cfg *config.Remote) (*tls.Config, error) {
+	tc := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file '%s'", cfg.CAFile)
+		}
+		tc.RootCAs = pool
+	}
+
+	if len(cfg.PinnedSHA256) > 0 {
+		pins := make(map[string]struct{}, len(cfg.PinnedSHA256))
+		for _, p := range cfg.PinnedSHA256 {
+			pins[p] = struct{}{}
+		}
+
+		// VerifyConnection also runs when InsecureSkipVerify is set, so a pin
+		// alone is enough to trust a self-signed development certificate.
+		tc.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			pin := SPKIPin(cs.PeerCertificates[0])
+			if _, ok := pins[pin]; !ok {
+				return fmt.Errorf("server certificate pin %s is not trusted", pin)
+			}
+			return nil
+		}
+	}
+
+	return tc, nil
+}
+
+// SPKIPin returns the base64 SHA-256 hash of a certificate's public key, the
+// format expected in config.Remote.PinnedSHA256.
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// CheckHealth calls the API's /health endpoint.
+func CheckHealth(ctx context.Context, client *http.Client, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
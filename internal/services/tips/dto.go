@@ -0,0 +1,94 @@
+package tips
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Category string
+
+const (
+	CategoryMovement   Category = "Movement"
+	CategorySurvival   Category = "Survival"
+	CategoryScoring    Category = "Scoring"
+	CategoryPsychology Category = "Psychology"
+)
+
+// categoryOrdinals maps the API's enum ordinals to categories; the API
+// serialises enums as integers.
+var categoryOrdinals = []Category{CategoryMovement, CategorySurvival, CategoryScoring, CategoryPsychology}
+
+// UnmarshalJSON accepts either the category's name or its enum ordinal.
+func (c *Category) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalEnum(b, "tip category", categoryOrdinals)
+	if err != nil {
+		return err
+	}
+	*c = s
+	return nil
+}
+
+// ordinal returns the API's enum value for c, or -1 if c is unknown.
+func (c Category) ordinal() int {
+	return ordinalOf(categoryOrdinals, c)
+}
+
+type Difficulty string
+
+const (
+	DifficultyBeginner     Difficulty = "Beginner"
+	DifficultyIntermediate Difficulty = "Intermediate"
+	DifficultyAdvanced     Difficulty = "Advanced"
+)
+
+var difficultyOrdinals = []Difficulty{DifficultyBeginner, DifficultyIntermediate, DifficultyAdvanced}
+
+// UnmarshalJSON accepts either the difficulty's name or its enum ordinal.
+func (d *Difficulty) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalEnum(b, "tip difficulty", difficultyOrdinals)
+	if err != nil {
+		return err
+	}
+	*d = s
+	return nil
+}
+
+func (d Difficulty) ordinal() int {
+	return ordinalOf(difficultyOrdinals, d)
+}
+
+type Tip struct {
+	TipID      string     `json:"tipId"`
+	Message    string     `json:"message"`
+	Category   Category   `json:"category"`
+	Difficulty Difficulty `json:"difficulty"`
+}
+
+type CollectionResponse[T any] struct {
+	Items []T `json:"items"`
+}
+
+func unmarshalEnum[T ~string](b []byte, what string, ordinals []T) (T, error) {
+	var n int
+	if err := json.Unmarshal(b, &n); err == nil {
+		if n < 0 || n >= len(ordinals) {
+			return "", fmt.Errorf("unknown %s %d", what, n)
+		}
+		return ordinals[n], nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return "", err
+	}
+	return T(s), nil
+}
+
+func ordinalOf[T comparable](ordinals []T, v T) int {
+	for i, o := range ordinals {
+		if o == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,199 @@
+package tips
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+)
+
+// Store keeps every known tip in memory, backed by a JSON cache file so tips
+// are still available when the API is unreachable.
+type Store struct {
+	service *TipsService // nil when the remote API is disabled
+	path    string
+
+	mu   sync.RWMutex
+	tips []Tip
+}
+
+// NewStore returns a store seeded from the cache at path. service may be nil,
+// in which case the store only ever serves cached tips, and an empty path
+// disables the cache.
+func NewStore(service *TipsService, path string) *Store {
+	s := &Store{service: service, path: path}
+
+	tips, err := readCache(path)
+	if err != nil {
+		log.Printf("load tips cache: %v", err)
+	}
+	s.tips = tips
+
+	return s
+}
+
+// Refresh replaces the known tips with the server's and rewrites the cache.
+// On failure the previously known tips are kept.
+func (s *Store) Refresh(ctx context.Context) (int, error) {
+	if s.service == nil {
+		return s.Len(), nil
+	}
+
+	tips, err := s.service.GetTips(ctx, Filter{})
+	if err != nil {
+		return 0, fmt.Errorf("fetch tips: %w", err)
+	}
+
+	s.mu.Lock()
+	s.tips = tips
+	s.mu.Unlock()
+
+	if err := writeCache(s.path, tips); err != nil {
+		return len(tips), fmt.Errorf("save tips cache: %w", err)
+	}
+	return len(tips), nil
+}
+
+// Add remembers a tip received from the live stream until the next Refresh.
+func (s *Store) Add(tip Tip) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.tips, func(t Tip) bool { return t.TipID == tip.TipID }) {
+		return
+	}
+	s.tips = append(s.tips, tip)
+}
+
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tips)
+}
+
+// Service returns the API client, or nil when the remote API is disabled.
+func (s *Store) Service() *TipsService {
+	return s.service
+}
+
+// Pick returns a random tip suited to mode and level. Tips for the level's
+// difficulty are preferred, narrowed to the categories that matter most in
+// mode. ok is false only when no tips are known at all.
+func (s *Store) Pick(mode data.GameMode, level int) (tip Tip, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.tips) == 0 {
+		return Tip{}, false
+	}
+
+	difficulty := DifficultyForLevel(level)
+	categories := categoriesForMode(mode)
+
+	var matching, sameDifficulty []Tip
+	for _, t := range s.tips {
+		if t.Difficulty != difficulty {
+			continue
+		}
+		sameDifficulty = append(sameDifficulty, t)
+		if categories == nil || slices.Contains(categories, t.Category) {
+			matching = append(matching, t)
+		}
+	}
+
+	for _, pool := range [][]Tip{matching, sameDifficulty, s.tips} {
+		if len(pool) > 0 {
+			return pool[rand.IntN(len(pool))], true
+		}
+	}
+	return Tip{}, false
+}
+
+// DifficultyForLevel maps a game level (1-10) to the tip difficulty worth
+// showing at that level.
+func DifficultyForLevel(level int) Difficulty {
+	switch {
+	case level <= 3:
+		return DifficultyBeginner
+	case level <= 6:
+		return DifficultyIntermediate
+	default:
+		return DifficultyAdvanced
+	}
+}
+
+// categoriesForMode returns the tip categories relevant to mode, or nil when
+// every category applies.
+func categoriesForMode(mode data.GameMode) []Category {
+	switch mode {
+	case data.GameModeCrazy:
+		// Bombs make staying alive and clean turns the main concern.
+		return []Category{CategorySurvival, CategoryMovement}
+	case data.GameModeAI:
+		// Racing the AI is about getting to food first.
+		return []Category{CategoryMovement, CategoryScoring}
+	default:
+		return nil
+	}
+}
+
+func readCache(path string) ([]Tip, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var tips []Tip
+	if err := json.Unmarshal(b, &tips); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	return tips, nil
+}
+
+// writeCache replaces the cache atomically so a crash mid-write never leaves
+// a truncated file behind.
+func writeCache(path string, tips []Tip) error {
+	if path == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(tips, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("replace cache: %w", err)
+	}
+	return nil
+}
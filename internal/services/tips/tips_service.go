@@ -0,0 +1,110 @@
+package tips
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/config"
+	"github.com/HilthonTT/gosnake/internal/services/remote"
+)
+
+type TipsService struct {
+	client  *remote.Client
+	stream  *http.Client // client without a timeout, for the realtime stream
+	baseURL string
+}
+
+// NewTipsService creates a client for the tips API described by cfg. Callers
+// should check cfg.Enabled first.
+func NewTipsService(cfg *config.Remote) (*TipsService, error) {
+	client, err := remote.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create http client: %w", err)
+	}
+
+	return &TipsService{
+		client:  client,
+		stream:  remote.StreamingClient(client.HTTPClient()),
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+	}, nil
+}
+
+// Filter narrows GetTips. Empty fields match everything. Note that the API
+// ignores Category whenever Difficulty is set.
+type Filter struct {
+	Difficulty Difficulty
+	Category   Category
+}
+
+func (s *TipsService) GetTips(ctx context.Context, filter Filter) ([]Tip, error) {
+	u, _ := url.Parse(s.baseURL + "/api/v1/tips")
+	q := u.Query()
+	if n := filter.Difficulty.ordinal(); n >= 0 {
+		q.Set("difficulty", strconv.Itoa(n))
+	}
+	if n := filter.Category.ordinal(); n >= 0 {
+		q.Set("category", strconv.Itoa(n))
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := remote.DoJSON[CollectionResponse[Tip]](s.client, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// StreamUpdate is sent by WatchTips whenever the connection state changes or
+// a tip arrives.
+type StreamUpdate struct {
+	State remote.StreamState
+
+	// Tip is set for tip events and nil for pure state updates.
+	Tip *Tip
+
+	// Err and RetryIn explain a remote.StreamDisconnected update.
+	Err     error
+	RetryIn time.Duration
+}
+
+// WatchTips follows the realtime tips stream until ctx is done, reconnecting
+// whenever the connection drops. The returned channel is closed once ctx is
+// done.
+func (s *TipsService) WatchTips(ctx context.Context) <-chan StreamUpdate {
+	updates := make(chan StreamUpdate)
+	raw := remote.WatchSSE(ctx, s.stream, s.baseURL+"/api/v1/tips/realtime")
+
+	go func() {
+		defer close(updates)
+
+		for u := range raw {
+			update := StreamUpdate{State: u.State, Err: u.Err, RetryIn: u.RetryIn}
+			if u.Event != nil {
+				var tip Tip
+				if err := json.Unmarshal([]byte(u.Event.Data), &tip); err != nil || tip.Message == "" {
+					continue
+				}
+				update.Tip = &tip
+			}
+
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates
+}
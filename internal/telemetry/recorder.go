@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/HilthonTT/gosnake/pkg/snake"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -40,6 +41,8 @@ func (r *Recorder) Record(msg tea.Msg) {
 		rec.Detail = fmt.Sprintf("%dx%d", m.Width, m.Height)
 	case tea.MouseMsg:
 		rec.Detail = fmt.Sprintf("mouse %d,%d", m.X, m.Y)
+	case snake.Event:
+		rec.Detail = m.String()
 	}
 
 	if len(r.buf) >= r.size {
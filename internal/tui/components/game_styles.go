@@ -19,6 +19,8 @@ const (
 	colBomb        = lipgloss.Color("196") // bright red  — active/lethal bomb
 	colBombWarning = lipgloss.Color("214") // amber       — blinking pre-warning
 	colAILabel     = lipgloss.Color("39")  // cyan — AI section label in info panel
+	colEventNote   = lipgloss.Color("248") // light grey for transient event messages
+	colTip         = lipgloss.Color("116") // pale cyan for gameplay tips
 )
 
 // CellCharacters holds the two-rune wide strings used for each cell type.
@@ -48,6 +50,7 @@ type InfoStyles struct {
 type OverlayStyles struct {
 	Paused   lipgloss.Style
 	GameOver lipgloss.Style
+	Tip      lipgloss.Style // tip shown beneath the overlay text
 }
 
 // GameStyles is the single source of truth for all visual styling.
@@ -65,6 +68,8 @@ type GameStyles struct {
 	Overlay         OverlayStyles
 	CellChars       CellCharacters
 	Help            lipgloss.Style
+	Event           lipgloss.Style // transient game event line under the board
+	Tip             lipgloss.Style // live tip ticker under the board
 }
 
 // CreateGameStyles returns a fully populated GameStyles with the default theme.
@@ -145,11 +150,22 @@ func CreateGameStyles() *GameStyles {
 				Foreground(colGameOver).
 				Bold(true).
 				Padding(0, 2),
+
+			Tip: lipgloss.NewStyle().
+				Foreground(colTip).
+				Italic(true).
+				Align(lipgloss.Center),
 		},
 
 		// Help bar
 		Help: lipgloss.NewStyle().Foreground(colMuted),
 
+		// Event line
+		Event: lipgloss.NewStyle().Foreground(colEventNote).Italic(true),
+
+		// Tip ticker
+		Tip: lipgloss.NewStyle().Foreground(colTip),
+
 		// Characters
 		CellChars: CellCharacters{
 			Empty:       "· ",
@@ -1,6 +1,9 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/HilthonTT/gosnake/internal/services/tips"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 // FatalErrorMsg encloses an error which should be set on the starter model before exiting the program.
 type FatalErrorMsg error
@@ -11,3 +14,26 @@ func FatalErrorCmd(err error) tea.Cmd {
 		return FatalErrorMsg(err)
 	}
 }
+
+// LeaderboardSyncedMsg reports the outcome of syncing the local leaderboard
+// with the remote one.
+type LeaderboardSyncedMsg struct {
+	Pulled int // remote entries added or linked locally
+	Pushed int // local entries accepted by the server
+
+	// Pending is the number of local entries still waiting to reach the
+	// server. It is only meaningful when Err is nil.
+	Pending int
+	Err     error
+}
+
+// TipsRefreshedMsg reports the outcome of fetching tips from the server.
+type TipsRefreshedMsg struct {
+	Count int
+	Err   error
+}
+
+// LiveTipMsg carries a tip pushed by the server's realtime tips stream.
+type LiveTipMsg struct {
+	Tip tips.Tip
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -71,7 +72,9 @@ func (in *SingleInput) isSwitchModeInput() {}
 
 type LeaderboardInput struct {
 	NewEntry *data.LeaderboardEntry
-	Entries  []data.LeaderboardEntry
+
+	// Replay is the recording of the run behind NewEntry, if any.
+	Replay *replay.Replay
 }
 
 func NewLeaderboardInput(opts ...func(input *LeaderboardInput)) *LeaderboardInput {
@@ -92,6 +95,8 @@ func WithNewEntry(entry *data.LeaderboardEntry) func(input *LeaderboardInput) {
 	}
 }
 
-func (i *LeaderboardInput) SetEntries(entries []data.LeaderboardEntry) {
-	i.Entries = entries
+func WithReplay(r *replay.Replay) func(input *LeaderboardInput) {
+	return func(input *LeaderboardInput) {
+		input.Replay = r
+	}
 }
@@ -1,22 +1,41 @@
 package starter
 
 import (
+	"context"
+	"crypto/ed25519"
 	"database/sql"
 	"errors"
 	"fmt"
+	"log"
 	"reflect"
+	"time"
 
 	"github.com/Broderick-Westrope/charmutils"
 	"github.com/HilthonTT/gosnake/internal/config"
 	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/internal/identity"
+	"github.com/HilthonTT/gosnake/internal/services/leaderboard"
+	"github.com/HilthonTT/gosnake/internal/services/tips"
 	"github.com/HilthonTT/gosnake/internal/telemetry"
 	"github.com/HilthonTT/gosnake/internal/tui"
 	"github.com/HilthonTT/gosnake/internal/tui/views"
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/adrg/xdg"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-const defaultRecorderSize = 500
+const (
+	defaultRecorderSize = 500
+
+	// syncInterval is how often the local leaderboard is synced with the
+	// remote one while the app is open. Each queued score's own backoff
+	// decides whether it is actually retried.
+	syncInterval = time.Minute
+)
+
+// syncTickMsg triggers a periodic leaderboard sync.
+type syncTickMsg struct{}
 
 type Input struct {
 	mode     tui.Mode
@@ -45,6 +64,19 @@ type Model struct {
 	recorder        *telemetry.Recorder
 	currentMode     tui.Mode
 
+	// syncer keeps the local and remote leaderboards in step; nil when the
+	// remote leaderboard is disabled.
+	syncer *leaderboard.Syncer
+
+	// tips serves cached tips to every view; liveTips is the realtime tips
+	// stream, nil when the remote API is disabled.
+	tips     *tips.Store
+	liveTips <-chan tips.StreamUpdate
+
+	// signingKey signs finished runs; nil if the install key is unusable,
+	// in which case runs are submitted unsigned.
+	signingKey ed25519.PrivateKey
+
 	width  int
 	height int
 
@@ -61,7 +93,38 @@ func NewModel(in *Input) (*Model, error) {
 		currentMode:     in.mode,
 	}
 
-	err := m.setChild(in.mode, in.switchIn)
+	// The remote leaderboard is optional; a bad remote config should never
+	// stop anyone from playing.
+	var tipsService *tips.TipsService
+	if in.cfg.Remote.Enabled {
+		service, err := leaderboard.NewLeaderboardService(in.cfg.Remote)
+		if err != nil {
+			log.Printf("remote leaderboard disabled: %v", err)
+		} else {
+			outbox := leaderboard.NewOutbox(data.NewOutboxRepository(in.db), service)
+			m.syncer = leaderboard.NewSyncer(m.leaderboardRepo, outbox, service)
+		}
+
+		tipsService, err = tips.NewTipsService(in.cfg.Remote)
+		if err != nil {
+			log.Printf("remote tips disabled: %v", err)
+		}
+	}
+
+	// Cached tips are shown even when offline.
+	tipsCache, err := xdg.CacheFile("gosnake/tips.json")
+	if err != nil {
+		log.Printf("tips cache disabled: %v", err)
+	}
+	m.tips = tips.NewStore(tipsService, tipsCache)
+
+	if path, err := identity.DefaultPath(); err != nil {
+		log.Printf("runs will not be signed: %v", err)
+	} else if m.signingKey, err = identity.LoadOrCreate(path); err != nil {
+		log.Printf("runs will not be signed: %v", err)
+	}
+
+	err = m.setChild(in.mode, in.switchIn)
 	if err != nil {
 		return nil, fmt.Errorf("setting child model: %w", err)
 	}
@@ -89,7 +152,26 @@ func (m *Model) GameSnapshot() map[string]any {
 }
 
 func (m *Model) Init() tea.Cmd {
-	return m.initChild()
+	cmds := []tea.Cmd{m.initChild()}
+
+	if m.syncer != nil {
+		// Push anything left over from previous sessions straight away.
+		cmds = append(cmds, views.SyncLeaderboardCmd(m.syncer), syncTick())
+	}
+
+	if service := m.tips.Service(); service != nil {
+		// The stream lives as long as the program does.
+		m.liveTips = service.WatchTips(context.Background())
+		cmds = append(cmds, views.RefreshTipsCmd(m.tips), views.WaitForLiveTipCmd(m.liveTips))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func syncTick() tea.Cmd {
+	return tea.Tick(syncInterval, func(time.Time) tea.Msg {
+		return syncTickMsg{}
+	})
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -98,6 +180,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tui.FatalErrorMsg:
 		m.ExitError = msg
+		m.closeChild()
 		return m, tea.Quit
 
 	case tea.KeyMsg:
@@ -105,13 +188,37 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 	case tui.SwitchModeMsg:
+		m.closeChild()
 		err := m.setChild(msg.Target, msg.Input)
 		if err != nil {
 			return m, tui.FatalErrorCmd(fmt.Errorf("setting child model: %w", err))
 		}
 		cmd := m.initChild()
+		if msg.Target == tui.ModeLeaderboard {
+			// Push the entry just saved and refresh the global view.
+			cmd = tea.Batch(cmd, views.SyncLeaderboardCmd(m.syncer))
+		}
 		return m, cmd
 
+	case syncTickMsg:
+		return m, tea.Batch(views.SyncLeaderboardCmd(m.syncer), syncTick())
+
+	case tui.LeaderboardSyncedMsg:
+		if msg.Err != nil {
+			log.Printf("sync leaderboard: %v", msg.Err)
+		}
+
+	case tui.TipsRefreshedMsg:
+		if msg.Err != nil {
+			log.Printf("refresh tips: %v", msg.Err)
+		}
+
+	case tui.LiveTipMsg:
+		m.tips.Add(msg.Tip)
+		var cmd tea.Cmd
+		m.child, cmd = m.child.Update(msg)
+		return m, tea.Batch(cmd, views.WaitForLiveTipCmd(m.liveTips))
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -137,17 +244,21 @@ func (m *Model) setChild(mode tui.Mode, switchIn tui.SwitchModeInput) error {
 		if !ok {
 			return fmt.Errorf("switchIn is not a MenuInput: %w", charmutils.ErrInvalidTypeAssertion)
 		}
-		m.child = views.NewMenuModel(menuIn)
+		m.child = views.NewMenuModel(menuIn, m.tips)
 
 	case tui.ModeNormal, tui.ModeCrazy, tui.ModeAI:
 		singleIn, ok := switchIn.(*tui.SingleInput)
 		if !ok {
 			return fmt.Errorf("switchIn is not a SingleInput: %w", charmutils.ErrInvalidTypeAssertion)
 		}
-		child, err := views.NewSingleModel(singleIn, m.db)
+		child, err := views.NewSingleModel(singleIn, m.db, m.tips)
 		if err != nil {
 			return fmt.Errorf("creating single model: %w", err)
 		}
+		// Log game events alongside Bubble Tea messages for crash reports.
+		child.Events().Subscribe(func(e snake.Event) {
+			m.recorder.Record(e)
+		})
 		m.child = child
 
 	case tui.ModeLeaderboard:
@@ -161,25 +272,27 @@ func (m *Model) setChild(mode tui.Mode, switchIn tui.SwitchModeInput) error {
 			if leaderboardIn.NewEntry.Name == "" {
 				leaderboardIn.NewEntry.Name = "Anonymous"
 			}
-			id, err := m.leaderboardRepo.Save(
-				leaderboardIn.NewEntry.Name,
-				leaderboardIn.NewEntry.Score,
-				leaderboardIn.NewEntry.Level,
-				leaderboardIn.NewEntry.Mode,
-			)
+			id, err := m.leaderboardRepo.SaveEntry(*leaderboardIn.NewEntry)
 			if err != nil {
 				return fmt.Errorf("saving leaderboard entry: %w", err)
 			}
 			leaderboardIn.NewEntry.ID = id
+
+			if leaderboardIn.Replay != nil {
+				path, err := m.signRun(*leaderboardIn.NewEntry, *leaderboardIn.Replay)
+				if err != nil {
+					log.Printf("sign run: %v", err)
+				} else {
+					log.Printf("replay saved to %s", path)
+				}
+			}
 		}
 
-		entries, err := m.leaderboardRepo.All()
+		child, err := views.NewLeaderboardModel(leaderboardIn, m.leaderboardRepo, m.syncer)
 		if err != nil {
-			return fmt.Errorf("fetching leaderboard entries: %w", err)
+			return fmt.Errorf("creating leaderboard model: %w", err)
 		}
-		leaderboardIn.Entries = entries
-
-		m.child = views.NewLeaderboardModel(leaderboardIn)
+		m.child = child
 
 	default:
 		return errors.New("invalid mode")
@@ -188,6 +301,14 @@ func (m *Model) setChild(mode tui.Mode, switchIn tui.SwitchModeInput) error {
 	return nil
 }
 
+// closeChild releases whatever the current child holds open, such as the
+// leaderboard's realtime stream, before it is replaced.
+func (m *Model) closeChild() {
+	if c, ok := m.child.(interface{ Close() }); ok {
+		c.Close()
+	}
+}
+
 func (m *Model) initChild() tea.Cmd {
 	var cmds []tea.Cmd
 	cmd := m.child.Init()
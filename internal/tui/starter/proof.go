@@ -0,0 +1,58 @@
+package starter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+	"github.com/HilthonTT/gosnake/pkg/verify"
+	"github.com/adrg/xdg"
+)
+
+// signRun signs the run behind a freshly saved entry, attaches the proof to
+// the entry for submission and writes a replay file for `gosnake verify`. It
+// returns the replay file's path.
+func (m *Model) signRun(entry data.LeaderboardEntry, rep replay.Replay) (string, error) {
+	if m.signingKey == nil {
+		return "", fmt.Errorf("no signing key")
+	}
+
+	claim := verify.NewClaim(entry.Name, rep, replay.Result{
+		Score:       entry.Score,
+		Level:       entry.Level,
+		SnakeLength: entry.SnakeLength,
+	})
+	proof := verify.Sign(claim, m.signingKey)
+
+	b, err := json.Marshal(proof)
+	if err != nil {
+		return "", fmt.Errorf("marshal proof: %w", err)
+	}
+	r, err := json.Marshal(rep)
+	if err != nil {
+		return "", fmt.Errorf("marshal replay: %w", err)
+	}
+	if err := m.leaderboardRepo.SaveProof(entry.ID, string(b), string(r)); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("gosnake/replays/run-%s-%d.json", time.Now().UTC().Format("20060102-150405"), entry.ID)
+	path, err := xdg.DataFile(name)
+	if err != nil {
+		return "", fmt.Errorf("resolve replay path: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create replay file: %w", err)
+	}
+	defer f.Close()
+
+	if err := verify.WriteRun(f, verify.Run{Proof: proof, Replay: rep}); err != nil {
+		return "", fmt.Errorf("write replay file: %w", err)
+	}
+	return path, nil
+}
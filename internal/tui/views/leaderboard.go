@@ -1,11 +1,16 @@
 package views
 
 import (
-	"slices"
+	"context"
+	"fmt"
+	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/internal/services/leaderboard"
+	"github.com/HilthonTT/gosnake/internal/services/remote"
 	"github.com/HilthonTT/gosnake/internal/tui"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -15,40 +20,183 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-type sortColumn int
+const (
+	leaderboardPageSize = 15
+
+	// leaderboardSyncTimeout bounds a whole remote sync, across all its
+	// requests.
+	leaderboardSyncTimeout = time.Minute
+
+	// Rows added by live updates blink for highlightDuration, toggling every
+	// highlightFrame.
+	highlightDuration = 3 * time.Second
+	highlightFrame    = 250 * time.Millisecond
+)
+
+var (
+	tabStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#006400")).
+			Padding(0, 2)
+
+	activeTabStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00FF41")).
+			Bold(true).
+			Underline(true).
+			Padding(0, 2)
+
+	liveBadgeStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF41"))
+	connectingBadgeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700"))
+	offlineBadgeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F5F"))
+)
+
+// leaderboardStreamMsg carries one update from the realtime leaderboard
+// stream. ok is false once the stream has closed.
+type leaderboardStreamMsg struct {
+	update leaderboard.StreamUpdate
+	ok     bool
+}
+
+// leaderboardAppliedMsg reports a realtime change mirrored into the local
+// leaderboard by applyChangeCmd.
+type leaderboardAppliedMsg struct {
+	change leaderboard.LeaderboardChangeEvent
+	entry  data.LeaderboardEntry
+	ok     bool
+	err    error
+}
+
+// highlightTickMsg advances the blink of live-added rows.
+type highlightTickMsg struct{}
+
+// leaderboardTab is one per-mode tab across the top of the view. The zero
+// mode shows every mode.
+type leaderboardTab struct {
+	title string
+	mode  data.GameMode
+}
+
+var leaderboardTabs = []leaderboardTab{
+	{title: "All"},
+	{title: "Normal", mode: data.GameModeNormal},
+	{title: "Crazy", mode: data.GameModeCrazy},
+	{title: "AI", mode: data.GameModeAI},
+}
+
+// leaderboardScopes is the cycle order of the local/global/merged toggle.
+var leaderboardScopes = []data.LeaderboardScope{data.ScopeMerged, data.ScopeLocal, data.ScopeGlobal}
+
+func nextScope(s data.LeaderboardScope) data.LeaderboardScope {
+	for i, scope := range leaderboardScopes {
+		if scope == s {
+			return leaderboardScopes[(i+1)%len(leaderboardScopes)]
+		}
+	}
+	return data.ScopeMerged
+}
+
+// timeWindow limits the view to recent entries.
+type timeWindow int
 
 const (
-	sortByScore sortColumn = iota
-	sortByLevel
-	sortByName
-	sortByMode
-	sortByDate
+	windowAllTime timeWindow = iota
+	windowToday
+	windowWeek
 )
 
+var timeWindowToStrMap = map[timeWindow]string{
+	windowAllTime: "All time",
+	windowToday:   "Today",
+	windowWeek:    "This week",
+}
+
+func (w timeWindow) String() string {
+	return timeWindowToStrMap[w]
+}
+
+// next cycles all time → today → this week → all time.
+func (w timeWindow) next() timeWindow {
+	return (w + 1) % timeWindow(len(timeWindowToStrMap))
+}
+
+// since returns the earliest creation time included by the window, or the
+// zero time for no limit. Weeks start on Monday.
+func (w timeWindow) since(now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch w {
+	case windowToday:
+		return midnight
+	case windowWeek:
+		offset := (int(midnight.Weekday()) + 6) % 7
+		return midnight.AddDate(0, 0, -offset)
+	default:
+		return time.Time{}
+	}
+}
+
 type sortState struct {
-	col  sortColumn
+	col  data.LeaderboardOrder
 	desc bool
 }
 
 var _ tea.Model = &LeaderboardModel{}
 
 type LeaderboardModel struct {
-	keys    *leaderboardKeyMap
-	help    help.Model
-	table   table.Model
+	keys  *leaderboardKeyMap
+	help  help.Model
+	table table.Model
+	repo  *data.LeaderboardRepository
+
+	// entries holds only the current page; total is the size of the whole
+	// filtered result set.
 	entries []data.LeaderboardEntry
-	focusID int
-	sort    sortState
-	width   int
-	height  int
-	search  textinput.Model
+	total   int
+	page    int
+
+	tab    int
+	window timeWindow
+	scope  data.LeaderboardScope
+
+	// focusID marks the highlighted entry; focusName is the player whose best
+	// score the "my best" key jumps to.
+	focusID   int
+	focusName string
+
+	// syncer is nil when the remote leaderboard is disabled. pendingSync is
+	// the number of local scores not yet accepted by the remote leaderboard.
+	syncer      *leaderboard.Syncer
+	pendingSync int
+
+	// stream delivers realtime changes while the view is open; it is nil when
+	// the remote leaderboard is disabled.
+	stream      <-chan leaderboard.StreamUpdate
+	stopStream  context.CancelFunc
+	streamState remote.StreamState
+	retryIn     time.Duration
+
+	// highlights maps rows added by live updates to when they arrived.
+	highlights map[int]time.Time
+	animating  bool
+
+	sort   sortState
+	status string
+	width  int
+	height int
+	search textinput.Model
 }
 
-func NewLeaderboardModel(in *tui.LeaderboardInput) *LeaderboardModel {
-	focusID := 0
-	if in.NewEntry != nil {
-		focusID = in.NewEntry.ID
+func NewLeaderboardModel(in *tui.LeaderboardInput, repo *data.LeaderboardRepository, syncer *leaderboard.Syncer) (*LeaderboardModel, error) {
+	names, err := repo.Names()
+	if err != nil {
+		return nil, err
+	}
+
+	var pendingSync int
+	if syncer != nil {
+		if pendingSync, err = syncer.Pending(); err != nil {
+			return nil, err
+		}
 	}
+
 	si := textinput.New()
 	si.Placeholder = "search by name..."
 	si.CharLimit = 100
@@ -56,24 +204,58 @@ func NewLeaderboardModel(in *tui.LeaderboardInput) *LeaderboardModel {
 	si.PromptStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF41"))
 	si.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
 	si.ShowSuggestions = true
-	si.SetSuggestions(uniqueNames(in.Entries))
+	si.SetSuggestions(names)
 
-	defaultSort := sortState{col: sortByScore, desc: true}
+	defaultSort := sortState{col: data.OrderByScore, desc: true}
 
 	m := &LeaderboardModel{
-		keys:    defaultLeaderboardKeyMap(),
-		help:    help.New(),
-		entries: in.Entries,
-		focusID: focusID,
-		search:  si,
-		sort:    defaultSort,
+		keys:        defaultLeaderboardKeyMap(),
+		help:        help.New(),
+		repo:        repo,
+		search:      si,
+		sort:        defaultSort,
+		syncer:      syncer,
+		pendingSync: pendingSync,
+		highlights:  make(map[int]time.Time),
+	}
+
+	if in.NewEntry != nil {
+		m.focusName = in.NewEntry.Name
+		if err := m.jumpTo(in.NewEntry.ID); err != nil {
+			return nil, err
+		}
+		return m, nil
 	}
-	m.table = buildLeaderboardTable(m.filteredSorted(), focusID, 0)
-	return m
+
+	if err := m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (m *LeaderboardModel) Init() tea.Cmd {
-	return nil
+	if m.syncer == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stopStream = cancel
+	m.stream = m.syncer.Watch(ctx)
+	return m.waitForStream()
+}
+
+func (m *LeaderboardModel) waitForStream() tea.Cmd {
+	stream := m.stream
+	return func() tea.Msg {
+		u, ok := <-stream
+		return leaderboardStreamMsg{update: u, ok: ok}
+	}
+}
+
+func highlightTick() tea.Cmd {
+	return tea.Tick(highlightFrame, func(time.Time) tea.Msg {
+		return highlightTickMsg{}
+	})
 }
 
 func (m *LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -87,15 +269,25 @@ func (m *LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.search.Blur()
 				return m, nil
 			}
+			prev := m.search.Value()
 			var cmd tea.Cmd
 			m.search, cmd = m.search.Update(msg)
 			cmds = append(cmds, cmd)
-			m.rebuildTable()
+			if m.search.Value() != prev {
+				m.page = 0
+				if err := m.reload(); err != nil {
+					return m, tui.FatalErrorCmd(err)
+				}
+			}
 			return m, tea.Batch(cmds...)
 		}
 
+		m.status = ""
+
+		var err error
 		switch {
 		case key.Matches(msg, m.keys.Exit):
+			m.Close()
 			return m, tui.SwitchModeCmd(tui.ModeMenu, tui.NewMenuInput())
 		case key.Matches(msg, m.keys.Help):
 			m.help.ShowAll = !m.help.ShowAll
@@ -103,23 +295,109 @@ func (m *LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, m.search.Focus())
 			return m, tea.Batch(cmds...)
 
+		case key.Matches(msg, m.keys.NextTab):
+			m.tab = (m.tab + 1) % len(leaderboardTabs)
+			m.page = 0
+			err = m.reload()
+		case key.Matches(msg, m.keys.PrevTab):
+			m.tab = (m.tab + len(leaderboardTabs) - 1) % len(leaderboardTabs)
+			m.page = 0
+			err = m.reload()
+		case key.Matches(msg, m.keys.Window):
+			m.window = m.window.next()
+			m.page = 0
+			err = m.reload()
+		case key.Matches(msg, m.keys.Scope):
+			m.scope = nextScope(m.scope)
+			m.page = 0
+			err = m.reload()
+		case key.Matches(msg, m.keys.NextPage):
+			if m.page < m.lastPage() {
+				m.page++
+				err = m.reload()
+			}
+		case key.Matches(msg, m.keys.PrevPage):
+			if m.page > 0 {
+				m.page--
+				err = m.reload()
+			}
+		case key.Matches(msg, m.keys.MyBest):
+			err = m.jumpToBest()
+
 		case key.Matches(msg, m.keys.SortScore):
-			m.toggleSort(sortByScore)
+			err = m.toggleSort(data.OrderByScore)
 		case key.Matches(msg, m.keys.SortLevel):
-			m.toggleSort(sortByLevel)
+			err = m.toggleSort(data.OrderByLevel)
 		case key.Matches(msg, m.keys.SortName):
-			m.toggleSort(sortByName)
+			err = m.toggleSort(data.OrderByName)
 		case key.Matches(msg, m.keys.SortMode):
-			m.toggleSort(sortByMode)
+			err = m.toggleSort(data.OrderByMode)
 		case key.Matches(msg, m.keys.SortDate):
-			m.toggleSort(sortByDate)
+			err = m.toggleSort(data.OrderByDate)
+
+		default:
+			// Only unhandled keys reach the table; its own keymap binds some
+			// of the same letters (b, d) and page keys.
+			var cmd tea.Cmd
+			m.table, cmd = m.table.Update(msg)
+			return m, cmd
+		}
+		if err != nil {
+			return m, tui.FatalErrorCmd(err)
 		}
+		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.rebuildTable()
 		return m, nil
+
+	case tui.LeaderboardSyncedMsg:
+		if msg.Err != nil {
+			return m, nil
+		}
+		m.pendingSync = msg.Pending
+		if msg.Pulled > 0 || msg.Pushed > 0 {
+			if err := m.refresh(); err != nil {
+				return m, tui.FatalErrorCmd(err)
+			}
+		}
+		return m, nil
+
+	case leaderboardStreamMsg:
+		if !msg.ok {
+			return m, nil
+		}
+		if cmd := m.onStreamUpdate(msg.update); cmd != nil {
+			// The next update is awaited once this one is applied, so
+			// changes are mirrored in the order they arrived.
+			return m, cmd
+		}
+		return m, m.waitForStream()
+
+	case leaderboardAppliedMsg:
+		// A live update that can't be mirrored (say the database is busy)
+		// is reported and skipped; the next sync picks it up.
+		cmd, err := m.onApplied(msg)
+		if err != nil {
+			log.Printf("leaderboard stream: %v", err)
+			m.status = "live update skipped: " + err.Error()
+		}
+		return m, tea.Batch(cmd, m.waitForStream())
+
+	case highlightTickMsg:
+		for id, at := range m.highlights {
+			if time.Since(at) >= highlightDuration {
+				delete(m.highlights, id)
+			}
+		}
+		m.table.SetRows(leaderboardRows(m.entries, m.focusID, m.marks()))
+		if len(m.highlights) == 0 {
+			m.animating = false
+			return m, nil
+		}
+		return m, highlightTick()
 	}
 
 	var cmd tea.Cmd
@@ -136,13 +414,21 @@ func (m *LeaderboardModel) View() string {
 	if m.search.Focused() {
 		searchView = m.search.View()
 	} else {
-		searchView = hintStyle.Render("press / to search  •  s=score  l=level  n=name  m=mode  d=date")
+		searchView = hintStyle.Render("press / to search  •  tab=mode  w=window  v=source  b=my best  ←/→=page")
+	}
+
+	footer := m.pageHint()
+	if m.status != "" {
+		footer = hintStyle.Render(m.status)
 	}
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
+		m.tabsView(),
+		"",
 		sortHint,
 		m.table.View(),
+		footer,
 		"",
 		searchView,
 		m.help.View(m.keys),
@@ -150,13 +436,57 @@ func (m *LeaderboardModel) View() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 }
 
+// tabsView renders the mode tabs and the active time window.
+func (m *LeaderboardModel) tabsView() string {
+	tabs := make([]string, len(leaderboardTabs))
+	for i, t := range leaderboardTabs {
+		if i == m.tab {
+			tabs[i] = activeTabStyle.Render(t.title)
+		} else {
+			tabs[i] = tabStyle.Render(t.title)
+		}
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Center,
+		lipgloss.JoinHorizontal(lipgloss.Center, tabs...),
+		hintStyle.Render("   "+m.window.String()+"  •  "+m.scope.String()),
+		m.streamBadge(),
+	)
+}
+
+// streamBadge shows the state of the realtime connection, if there is one.
+func (m *LeaderboardModel) streamBadge() string {
+	if m.stream == nil {
+		return ""
+	}
+	switch m.streamState {
+	case remote.StreamLive:
+		return liveBadgeStyle.Render("   ● live")
+	case remote.StreamDisconnected:
+		return offlineBadgeStyle.Render(fmt.Sprintf("   ○ offline, retrying in %s", m.retryIn.Round(time.Second)))
+	default:
+		return connectingBadgeStyle.Render("   ◌ connecting")
+	}
+}
+
+func (m *LeaderboardModel) pageHint() string {
+	hint := fmt.Sprintf("page %d/%d  •  %d entries", m.page+1, m.lastPage()+1, m.total)
+	switch {
+	case m.pendingSync == 1:
+		hint += "  •  1 score pending sync"
+	case m.pendingSync > 1:
+		hint += fmt.Sprintf("  •  %d scores pending sync", m.pendingSync)
+	}
+	return hintStyle.Render(hint)
+}
+
 func (m *LeaderboardModel) sortHint() string {
-	names := map[sortColumn]string{
-		sortByScore: "Score",
-		sortByLevel: "Level",
-		sortByName:  "Name",
-		sortByMode:  "Mode",
-		sortByDate:  "Date",
+	names := map[data.LeaderboardOrder]string{
+		data.OrderByScore: "Score",
+		data.OrderByLevel: "Level",
+		data.OrderByName:  "Name",
+		data.OrderByMode:  "Mode",
+		data.OrderByDate:  "Date",
 	}
 	dir := "↓"
 	if !m.sort.desc {
@@ -165,12 +495,11 @@ func (m *LeaderboardModel) sortHint() string {
 
 	str := "sorted by: " + names[m.sort.col] + " " + dir
 	str += "\n"
-	str += "\n"
 
 	return hintStyle.Render(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, str))
 }
 
-func buildLeaderboardTable(entries []data.LeaderboardEntry, focusID int, termWidth int) table.Model {
+func buildLeaderboardTable(entries []data.LeaderboardEntry, focusID int, marks map[int]string, termWidth int) table.Model {
 	nameWidth := 16
 	for _, e := range entries {
 		if len(e.Name) > nameWidth {
@@ -198,17 +527,46 @@ func buildLeaderboardTable(entries []data.LeaderboardEntry, focusID int, termWid
 	}
 
 	focusIndex := 0
-	rows := make([]table.Row, len(entries))
-
 	for i, e := range entries {
 		if e.ID == focusID {
 			focusIndex = i
 		}
+	}
 
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(leaderboardRows(entries, focusID, marks)),
+		table.WithFocused(true),
+		table.WithStyles(s),
+	)
+	t.SetCursor(focusIndex)
+
+	return t
+}
+
+// leaderboardRows renders entries as table rows. marks holds a prefix for the
+// names of rows that should stand out, such as ones added by live updates.
+func leaderboardRows(entries []data.LeaderboardEntry, focusID int, marks map[int]string) []table.Row {
+	rows := make([]table.Row, len(entries))
+
+	for i, e := range entries {
 		name := e.Name
 		// Visually mark the player's own new entry so it stands out immediately.
 		if e.ID == focusID {
 			name = "▶ " + name
+		} else if mark, ok := marks[e.ID]; ok {
+			name = mark + name
 		}
 
 		date := e.CreatedAt
@@ -230,29 +588,10 @@ func buildLeaderboardTable(entries []data.LeaderboardEntry, focusID int, termWid
 		}
 	}
 
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(false)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(false)
-
-	t := table.New(
-		table.WithColumns(cols),
-		table.WithRows(rows),
-		table.WithFocused(true),
-		table.WithStyles(s),
-	)
-	t.SetCursor(focusIndex)
-
-	return t
+	return rows
 }
 
-func (m *LeaderboardModel) toggleSort(col sortColumn) {
+func (m *LeaderboardModel) toggleSort(col data.LeaderboardOrder) error {
 	if m.sort.col == col {
 		m.sort.desc = !m.sort.desc
 	} else {
@@ -260,59 +599,214 @@ func (m *LeaderboardModel) toggleSort(col sortColumn) {
 		m.sort.desc = true
 	}
 
-	m.rebuildTable()
+	m.page = 0
+	return m.reload()
 }
 
-func (m *LeaderboardModel) rebuildTable() {
-	m.table = buildLeaderboardTable(m.filteredSorted(), m.focusID, m.width)
+// filter describes everything currently narrowing the view, excluding paging.
+func (m *LeaderboardModel) filter() data.LeaderboardFilter {
+	return data.LeaderboardFilter{
+		Scope:     m.scope,
+		Mode:      leaderboardTabs[m.tab].mode,
+		Search:    strings.TrimSpace(m.search.Value()),
+		Since:     m.window.since(time.Now()),
+		OrderBy:   m.sort.col,
+		Ascending: !m.sort.desc,
+	}
+}
+
+func (m *LeaderboardModel) lastPage() int {
+	if m.total == 0 {
+		return 0
+	}
+	return (m.total - 1) / leaderboardPageSize
 }
 
-func (m *LeaderboardModel) filteredSorted() []data.LeaderboardEntry {
-	needle := strings.ToLower(strings.TrimSpace(m.search.Value()))
+// reload fetches the current page from the database and rebuilds the table.
+func (m *LeaderboardModel) reload() error {
+	f := m.filter()
 
-	filtered := make([]data.LeaderboardEntry, 0, len(m.entries))
-	for _, e := range m.entries {
-		if needle == "" || strings.Contains(strings.ToLower(e.Name), needle) {
-			filtered = append(filtered, e)
-		}
+	total, err := m.repo.Count(f)
+	if err != nil {
+		return err
+	}
+	m.total = total
+	m.page = min(m.page, m.lastPage())
+
+	f.Limit = leaderboardPageSize
+	f.Offset = m.page * leaderboardPageSize
+	entries, err := m.repo.Find(f)
+	if err != nil {
+		return err
 	}
+	m.entries = entries
 
-	slices.SortFunc(filtered, func(a, b data.LeaderboardEntry) int {
-		var cmp int
-
-		switch m.sort.col {
-		case sortByScore:
-			cmp = a.Score - b.Score
-		case sortByLevel:
-			cmp = a.Level - b.Level
-		case sortByName:
-			cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
-		case sortByMode:
-			cmp = strings.Compare(string(a.Mode), string(b.Mode))
-		case sortByDate:
-			cmp = strings.Compare(a.CreatedAt, b.CreatedAt)
-		}
+	m.rebuildTable()
+	return nil
+}
+
+// jumpTo focuses entry id and switches to the page that contains it.
+func (m *LeaderboardModel) jumpTo(id int) error {
+	pos, ok, err := m.repo.Position(m.filter(), id)
+	if err != nil {
+		return err
+	}
 
-		if m.sort.desc {
-			return -cmp
+	m.focusID = id
+	if ok {
+		m.page = pos / leaderboardPageSize
+	}
+	return m.reload()
+}
+
+// jumpToBest scrolls to the focused player's highest score within the current
+// scope, tab and time window. Without a new entry to go by, the player on the
+// selected row is used.
+func (m *LeaderboardModel) jumpToBest() error {
+	name := m.focusName
+	if name == "" {
+		cursor := m.table.Cursor()
+		if cursor < 0 || cursor >= len(m.entries) {
+			return nil
 		}
+		name = m.entries[cursor].Name
+	}
 
-		return cmp
+	best, err := m.repo.Find(data.LeaderboardFilter{
+		Scope: m.scope,
+		Mode:  leaderboardTabs[m.tab].mode,
+		Name:  name,
+		Since: m.window.since(time.Now()),
+		Limit: 1,
 	})
+	if err != nil {
+		return err
+	}
+	if len(best) == 0 {
+		m.status = fmt.Sprintf("no scores for %s in this view", name)
+		return nil
+	}
 
-	return filtered
+	// The search box could hide the entry, so clear it before jumping.
+	m.search.SetValue("")
+	return m.jumpTo(best[0].ID)
 }
 
-func uniqueNames(entries []data.LeaderboardEntry) []string {
-	seen := make(map[string]struct{}, len(entries))
-	names := make([]string, len(entries))
+func (m *LeaderboardModel) rebuildTable() {
+	m.table = buildLeaderboardTable(m.entries, m.focusID, m.marks(), m.width)
+}
 
-	for _, e := range entries {
-		if _, ok := seen[e.Name]; !ok {
-			seen[e.Name] = struct{}{}
-			names = append(names, e.Name)
+// marks returns the blinking prefix for every row still highlighted after a
+// live update.
+func (m *LeaderboardModel) marks() map[int]string {
+	marks := make(map[int]string, len(m.highlights))
+	for id, at := range m.highlights {
+		if int(time.Since(at)/highlightFrame)%2 == 0 {
+			marks[id] = "✚ "
+		} else {
+			marks[id] = "  "
+		}
+	}
+	return marks
+}
+
+// refresh reloads the current page after a background change, keeping the
+// cursor on the same entry when it is still visible.
+func (m *LeaderboardModel) refresh() error {
+	selected := -1
+	if c := m.table.Cursor(); c >= 0 && c < len(m.entries) {
+		selected = m.entries[c].ID
+	}
+
+	if err := m.reload(); err != nil {
+		return err
+	}
+
+	for i, e := range m.entries {
+		if e.ID == selected {
+			m.table.SetCursor(i)
+			break
 		}
 	}
+	return nil
+}
+
+// onStreamUpdate records the realtime connection state and returns a command
+// mirroring any change into the local leaderboard, or nil if there is none.
+func (m *LeaderboardModel) onStreamUpdate(u leaderboard.StreamUpdate) tea.Cmd {
+	m.streamState = u.State
+	m.retryIn = u.RetryIn
+	if u.State == remote.StreamDisconnected {
+		log.Printf("leaderboard stream: %v", u.Err)
+	}
+
+	if u.Change == nil {
+		return nil
+	}
+	return applyChangeCmd(m.syncer, *u.Change)
+}
+
+// applyChangeCmd mirrors a realtime change off the UI goroutine, since it may
+// wait for a sync in progress.
+func applyChangeCmd(syncer *leaderboard.Syncer, change leaderboard.LeaderboardChangeEvent) tea.Cmd {
+	return func() tea.Msg {
+		entry, ok, err := syncer.Apply(change)
+		return leaderboardAppliedMsg{change: change, entry: entry, ok: ok, err: err}
+	}
+}
+
+// onApplied highlights or reports a mirrored change and refreshes the page.
+func (m *LeaderboardModel) onApplied(msg leaderboardAppliedMsg) (tea.Cmd, error) {
+	if msg.err != nil {
+		return nil, msg.err
+	}
+	if !msg.ok {
+		return nil, nil
+	}
 
-	return names
+	switch msg.change.ChangeType {
+	case leaderboard.EntryAdded:
+		m.highlights[msg.entry.ID] = time.Now()
+	case leaderboard.EntryDeleted:
+		m.status = fmt.Sprintf("%s's score of %d was removed", msg.entry.Name, msg.entry.Score)
+	}
+	if err := m.refresh(); err != nil {
+		return nil, err
+	}
+
+	if len(m.highlights) > 0 && !m.animating {
+		m.animating = true
+		return highlightTick(), nil
+	}
+	return nil, nil
+}
+
+// Close stops the realtime stream. The parent calls it whenever it replaces
+// the view, whichever way the view is left.
+func (m *LeaderboardModel) Close() {
+	if m.stopStream != nil {
+		m.stopStream()
+		m.stopStream = nil
+	}
+}
+
+// SyncLeaderboardCmd syncs the local leaderboard with the remote one in the
+// background and reports the result as a tui.LeaderboardSyncedMsg. It returns
+// nil when syncer is nil.
+func SyncLeaderboardCmd(syncer *leaderboard.Syncer) tea.Cmd {
+	if syncer == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), leaderboardSyncTimeout)
+		defer cancel()
+
+		res, err := syncer.Sync(ctx)
+		return tui.LeaderboardSyncedMsg{
+			Pulled:  res.Pulled,
+			Pushed:  res.Pushed,
+			Pending: res.Pending,
+			Err:     err,
+		}
+	}
 }
@@ -5,10 +5,15 @@ import "github.com/charmbracelet/bubbles/key"
 type leaderboardKeyMap struct {
 	Exit      key.Binding
 	Help      key.Binding
-	Left      key.Binding
-	Right     key.Binding
 	Up        key.Binding
 	Down      key.Binding
+	PrevPage  key.Binding
+	NextPage  key.Binding
+	PrevTab   key.Binding
+	NextTab   key.Binding
+	Window    key.Binding
+	Scope     key.Binding
+	MyBest    key.Binding
 	Search    key.Binding
 	SortScore key.Binding
 	SortLevel key.Binding
@@ -19,12 +24,32 @@ type leaderboardKeyMap struct {
 
 func defaultLeaderboardKeyMap() *leaderboardKeyMap {
 	return &leaderboardKeyMap{
-		Exit:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("escape", "exit")),
-		Help:  key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
-		Left:  key.NewBinding(key.WithKeys("left"), key.WithHelp("left arrow", "move left")),
-		Right: key.NewBinding(key.WithKeys("right"), key.WithHelp("right arrow", "move right")),
-		Up:    key.NewBinding(key.WithKeys("up"), key.WithHelp("up arrow", "move up")),
-		Down:  key.NewBinding(key.WithKeys("down"), key.WithHelp("down arrow", "move down")),
+		Exit:     key.NewBinding(key.WithKeys("esc"), key.WithHelp("escape", "exit")),
+		Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Up:       key.NewBinding(key.WithKeys("up"), key.WithHelp("up arrow", "move up")),
+		Down:     key.NewBinding(key.WithKeys("down"), key.WithHelp("down arrow", "move down")),
+		PrevPage: key.NewBinding(key.WithKeys("left", "pgup"), key.WithHelp("left arrow", "previous page")),
+		NextPage: key.NewBinding(key.WithKeys("right", "pgdown"), key.WithHelp("right arrow", "next page")),
+		PrevTab: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous mode"),
+		),
+		NextTab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next mode"),
+		),
+		Window: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "time window"),
+		),
+		Scope: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "local/global/merged"),
+		),
+		MyBest: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "my best"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search"),
@@ -65,17 +90,23 @@ func (k *leaderboardKeyMap) FullHelp() [][]key.Binding {
 			k.Exit,
 			k.Help,
 		},
-		{
-			k.Left,
-			k.Right,
-		},
 		{
 			k.Up,
 			k.Down,
 		},
+		{
+			k.PrevPage,
+			k.NextPage,
+		},
+		{
+			k.PrevTab,
+			k.NextTab,
+			k.Window,
+			k.Scope,
+		},
 		{
 			k.Search,
-			k.Search,
+			k.MyBest,
 		},
 		{k.SortScore, k.SortLevel, k.SortName},
 		{k.SortMode, k.SortDate},
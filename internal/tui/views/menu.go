@@ -2,6 +2,7 @@ package views
 
 import (
 	"github.com/Broderick-Westrope/charmutils"
+	"github.com/HilthonTT/gosnake/internal/services/tips"
 	"github.com/HilthonTT/gosnake/internal/tui"
 	"github.com/HilthonTT/gosnake/internal/tui/validate"
 	"github.com/charmbracelet/bubbles/key"
@@ -30,6 +31,11 @@ var (
 	hintStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#444444")).
 			Italic(true)
+
+	menuTipStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#5FAFAF")).
+			Italic(true).
+			Align(lipgloss.Center)
 )
 
 var _ tea.Model = &MenuModel{}
@@ -40,6 +46,11 @@ type MenuModel struct {
 	keys                   *menuKeyMap
 	formData               *MenuFormData
 
+	// tips supplies the tip of the day shown under the form.
+	tips   *tips.Store
+	tip    string
+	ticker tipTicker
+
 	width  int
 	height int
 }
@@ -50,12 +61,13 @@ type MenuFormData struct {
 	Level    int
 }
 
-func NewMenuModel(_ *tui.MenuInput) *MenuModel {
+func NewMenuModel(_ *tui.MenuInput, tipStore *tips.Store) *MenuModel {
 	formData := new(MenuFormData)
 	keys := defaultMenuKeyMap()
 
-	return &MenuModel{
+	m := &MenuModel{
 		formData: formData,
+		tips:     tipStore,
 		form: huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().
@@ -85,6 +97,9 @@ func NewMenuModel(_ *tui.MenuInput) *MenuModel {
 			WithShowHelp(true),
 		keys: keys,
 	}
+	m.pickTip()
+
+	return m
 }
 
 func (m *MenuModel) Init() tea.Cmd {
@@ -108,6 +123,17 @@ func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		formWidth := min(m.width/2, titleWidth)
 		m.form = m.form.WithWidth(formWidth)
 		return m, nil
+
+	case tui.TipsRefreshedMsg:
+		// The first launch has no cached tips until this arrives.
+		if m.tip == "" {
+			m.pickTip()
+		}
+		return m, nil
+
+	case tui.LiveTipMsg:
+		m.ticker.set(msg.Tip)
+		return m, nil
 	}
 
 	var cmds []tea.Cmd
@@ -130,15 +156,17 @@ func (m *MenuModel) View() string {
 	subtitle := subtitleStyle.Render("eat, grow, survive")
 	hint := hintStyle.Render("ctrl+c / esc to quit")
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Center,
-		title,
-		subtitle,
-		"",
-		m.form.View(),
-		"",
-		hint,
-	)
+	sections := []string{title, subtitle, "", m.form.View(), ""}
+	tipWidth := lipgloss.Width(TitleStr)
+	if m.tip != "" {
+		sections = append(sections, menuTipStyle.Width(tipWidth).Render(tipPrefix+m.tip), "")
+	}
+	if ticker := m.ticker.view(menuTipStyle, tipWidth); ticker != "" {
+		sections = append(sections, ticker, "")
+	}
+	sections = append(sections, hint)
+
+	content := lipgloss.JoinVertical(lipgloss.Center, sections...)
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 }
@@ -149,6 +177,16 @@ func (m *MenuModel) announceCompletion() tea.Cmd {
 	return tui.SwitchModeCmd(m.formData.GameMode, in)
 }
 
+// pickTip chooses a tip for the mode and level currently selected in the form.
+func (m *MenuModel) pickTip() {
+	if m.tips == nil {
+		return
+	}
+	if tip, ok := m.tips.Pick(gameModeFromTUI(m.formData.GameMode), max(m.formData.Level, 1)); ok {
+		m.tip = tip.Message
+	}
+}
+
 func greenTheme() *huh.Theme {
 	t := huh.ThemeBase()
 	t.Focused.Title = t.Focused.Title.Foreground(lipgloss.Color("#00FF41"))
@@ -1,15 +1,14 @@
 package views
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"math/rand/v2"
 	"strings"
 	"time"
 
 	"github.com/HilthonTT/gosnake/internal/data"
-	"github.com/HilthonTT/gosnake/internal/services/leaderboard"
+	"github.com/HilthonTT/gosnake/internal/services/tips"
 	"github.com/HilthonTT/gosnake/internal/telemetry"
 	"github.com/HilthonTT/gosnake/internal/tui"
 	"github.com/HilthonTT/gosnake/internal/tui/components"
@@ -17,6 +16,7 @@ import (
 	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
 	"github.com/HilthonTT/gosnake/pkg/snake/modes/crazy"
 	"github.com/HilthonTT/gosnake/pkg/snake/modes/single"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/stopwatch"
@@ -46,6 +46,9 @@ Press PAUSE to continue or HOLD to exit.
 
 	TimerUpdateInterval = time.Millisecond * 13
 
+	// eventNoteDuration is how long a game event message stays on screen.
+	eventNoteDuration = 2 * time.Second
+
 	bombBlinkPeriodMs = crazy.BombBlinkPeriodMs
 )
 
@@ -56,6 +59,10 @@ type SingleModel struct {
 	game     snake.GameController
 	mode     tui.Mode
 
+	// recorder captures the seed and inputs so the run can be replayed and
+	// verified later.
+	recorder *replay.Recorder
+
 	// tickStopwatch drives the snake's movement; its interval shrinks as the
 	// level rises
 	tickStopwatch components.Stopwatch
@@ -67,33 +74,43 @@ type SingleModel struct {
 	keys   *components.GameKeyMap
 	styles *components.GameStyles
 
-	leaderboardService *leaderboard.LeaderboardService
+	// eventNote is the latest game event worth telling the player about.
+	eventNote   string
+	eventNoteAt time.Time
+
+	// tips supplies the tip shown on the pause and game-over overlays;
+	// overlayTip is picked whenever one of them appears.
+	tips       *tips.Store
+	overlayTip string
+
+	ticker tipTicker
 
 	width  int
 	height int
 }
 
-func NewSingleModel(in *tui.SingleInput, db *sql.DB) (*SingleModel, error) {
+func NewSingleModel(in *tui.SingleInput, db *sql.DB, tipStore *tips.Store) (*SingleModel, error) {
 	repo := data.NewLeaderboardRepository(db)
 
 	var (
-		g   snake.GameController
-		err error
+		g    snake.GameController
+		err  error
+		seed = rand.Int64()
 	)
 
 	switch in.Mode {
 	case tui.ModeNormal:
-		g, err = single.NewGame(repo)
+		g, err = single.NewGame(repo, snake.WithSeed(seed))
 		if err != nil {
 			return nil, fmt.Errorf("creating normal snake game: %w", err)
 		}
 	case tui.ModeCrazy:
-		g, err = crazy.NewGame(repo)
+		g, err = crazy.NewGame(repo, snake.WithSeed(seed))
 		if err != nil {
 			return nil, fmt.Errorf("creating crazy snake game: %w", err)
 		}
 	case tui.ModeAI:
-		g, err = ai.NewGame(repo)
+		g, err = ai.NewGame(repo, snake.WithSeed(seed))
 		if err != nil {
 			return nil, fmt.Errorf("creating AI snake game: %w", err)
 		}
@@ -102,16 +119,18 @@ func NewSingleModel(in *tui.SingleInput, db *sql.DB) (*SingleModel, error) {
 	}
 
 	m := &SingleModel{
-		username:           in.Username,
-		help:               help.New(),
-		game:               g,
-		keys:               components.NewGameKeyMap(),
-		tickStopwatch:      components.NewStopwatchWithInterval(g.GetDefaultTickInterval()),
-		gameStopwatch:      components.NewStopwatchWithInterval(TimerUpdateInterval),
-		styles:             components.CreateGameStyles(),
-		leaderboardService: leaderboard.NewLeaderboardService(),
-		mode:               in.Mode,
+		username:      in.Username,
+		help:          help.New(),
+		game:          g,
+		keys:          components.NewGameKeyMap(),
+		tickStopwatch: components.NewStopwatchWithInterval(g.GetDefaultTickInterval()),
+		gameStopwatch: components.NewStopwatchWithInterval(TimerUpdateInterval),
+		styles:        components.CreateGameStyles(),
+		mode:          in.Mode,
+		recorder:      replay.NewRecorder(gameModeFromTUI(in.Mode), seed),
+		tips:          tipStore,
 	}
+	g.Events().Subscribe(m.onGameEvent)
 
 	return m, nil
 }
@@ -143,6 +162,9 @@ func (m *SingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, tea.Batch(cmds...)
+	case tui.LiveTipMsg:
+		m.ticker.set(msg.Tip)
+		return m, tea.Batch(cmds...)
 	}
 
 	// Route to the correct state handler.
@@ -173,6 +195,15 @@ func (m *SingleModel) View() string {
 		m.infoView(),
 		board,
 	)
+	if m.eventNote != "" && time.Since(m.eventNoteAt) < eventNoteDuration {
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			content,
+			m.styles.Event.Render(" "+m.eventNote),
+		)
+	}
+	if ticker := m.ticker.view(m.styles.Tip, lipgloss.Width(content)); ticker != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, ticker)
+	}
 	content = lipgloss.JoinVertical(lipgloss.Left,
 		content,
 		m.styles.Help.Render(m.help.View(m.keys)),
@@ -201,16 +232,18 @@ func (m *SingleModel) gameOverUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 		if key.Matches(msg, m.keys.Quit) {
 
 			newEntry := &data.LeaderboardEntry{
-				Name:      m.username,
-				Score:     m.game.Score(),
-				Level:     m.game.Level(),
-				CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
-				Mode:      gameModeFromTUI(m.mode),
+				Name:        m.username,
+				Score:       m.game.Score(),
+				Level:       m.game.Level(),
+				CreatedAt:   time.Now().Format("2006-01-02 15:04:05"),
+				Mode:        gameModeFromTUI(m.mode),
+				SnakeLength: m.game.SnakeLength(),
 			}
 
+			rep := m.recorder.Replay()
 			return m, tui.SwitchModeCmd(
 				tui.ModeLeaderboard,
-				tui.NewLeaderboardInput(tui.WithNewEntry(newEntry)),
+				tui.NewLeaderboardInput(tui.WithNewEntry(newEntry), tui.WithReplay(&rep)),
 			)
 		}
 	}
@@ -228,13 +261,13 @@ func (m *SingleModel) togglePause() tea.Cmd {
 func (m *SingleModel) playingKeyUpdate(msg tea.KeyMsg) (*SingleModel, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Up):
-		m.game.ChangeDirection(snake.Up)
+		m.changeDirection(snake.Up)
 	case key.Matches(msg, m.keys.Down):
-		m.game.ChangeDirection(snake.Down)
+		m.changeDirection(snake.Down)
 	case key.Matches(msg, m.keys.Left):
-		m.game.ChangeDirection(snake.Left)
+		m.changeDirection(snake.Left)
 	case key.Matches(msg, m.keys.Right):
-		m.game.ChangeDirection(snake.Right)
+		m.changeDirection(snake.Right)
 	case key.Matches(msg, m.keys.Pause):
 		return m, m.togglePause()
 	case key.Matches(msg, m.keys.Quit):
@@ -243,6 +276,11 @@ func (m *SingleModel) playingKeyUpdate(msg tea.KeyMsg) (*SingleModel, tea.Cmd) {
 	return m, nil
 }
 
+func (m *SingleModel) changeDirection(d snake.Direction) {
+	m.recorder.Input(d)
+	m.game.ChangeDirection(d)
+}
+
 func (m *SingleModel) pausedUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		switch {
@@ -266,13 +304,13 @@ func (m *SingleModel) playingUpdate(msg tea.Msg) (*SingleModel, tea.Cmd) {
 			break
 		}
 		m.game.Tick()
+		m.recorder.Tick()
 
 		// Adjust tick speed to match the (possibly new) level.
 		m.tickStopwatch.SetInterval(m.game.GetTickInterval())
 
 		if m.game.IsGameOver() {
-			m.submitScore()
-
+			m.pickOverlayTip()
 			return m, tea.Batch(
 				m.tickStopwatch.Stop(),
 				m.gameStopwatch.Stop(),
@@ -304,9 +342,16 @@ func (m *SingleModel) overlayView(style lipgloss.Style, msg string) string {
 		innerW = snake.DefaultCols * 2 // two chars per cell
 		innerH = snake.DefaultRows
 	)
+	content := style.Render(msg)
+	if m.overlayTip != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center,
+			content,
+			m.styles.Overlay.Tip.Width(innerW-4).Render(tipPrefix+m.overlayTip),
+		)
+	}
 	inner := lipgloss.Place(innerW, innerH,
 		lipgloss.Center, lipgloss.Center,
-		style.Render(msg),
+		content,
 	)
 	return m.styles.Board.Render(inner)
 }
@@ -427,20 +472,35 @@ func (m *SingleModel) infoView() string {
 	return s.Panel.Render(body)
 }
 
-func (m *SingleModel) submitScore() {
-	req := leaderboard.SubmitScoreRequest{
-		PlayerName:  m.username,
-		Score:       m.game.Score(),
-		Level:       m.game.Level(),
-		SnakeLength: m.game.SnakeLength(),
+// onGameEvent is subscribed to the game's event bus. It runs inside Tick, which
+// is only ever called from Update, so touching the model here is safe.
+func (m *SingleModel) onGameEvent(e snake.Event) {
+	switch e := e.(type) {
+	case snake.LevelUp, snake.SnakeDied, snake.AIKilled:
+		m.eventNote = e.String()
+		m.eventNoteAt = time.Now()
+	case snake.PauseToggled:
+		if e.Paused {
+			m.pickOverlayTip()
+		}
 	}
+}
 
-	go func() {
-		_, err := m.leaderboardService.SubmitScore(context.Background(), req)
-		if err != nil {
-			log.Printf("%s", err.Error())
-		}
-	}()
+// pickOverlayTip chooses a fresh tip for the current mode and level. The tip
+// is left empty when none are known.
+func (m *SingleModel) pickOverlayTip() {
+	m.overlayTip = ""
+	if m.tips == nil {
+		return
+	}
+	if tip, ok := m.tips.Pick(gameModeFromTUI(m.mode), m.game.Level()); ok {
+		m.overlayTip = tip.Message
+	}
+}
+
+// Events exposes the running game's event bus.
+func (m *SingleModel) Events() *snake.EventBus {
+	return m.game.Events()
 }
 
 func gameModeFromTUI(m tui.Mode) data.GameMode {
@@ -0,0 +1,79 @@
+package views
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/services/remote"
+	"github.com/HilthonTT/gosnake/internal/services/tips"
+	"github.com/HilthonTT/gosnake/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// tipsRefreshTimeout bounds fetching the full tip list.
+	tipsRefreshTimeout = 15 * time.Second
+
+	// tipTickerDuration is how long a live tip stays in the ticker once the
+	// stream goes quiet.
+	tipTickerDuration = 30 * time.Second
+
+	tipPrefix = "💡 "
+)
+
+// RefreshTipsCmd fetches the latest tips into store in the background and
+// reports the result as a tui.TipsRefreshedMsg. It returns nil when store is
+// nil.
+func RefreshTipsCmd(store *tips.Store) tea.Cmd {
+	if store == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), tipsRefreshTimeout)
+		defer cancel()
+
+		n, err := store.Refresh(ctx)
+		return tui.TipsRefreshedMsg{Count: n, Err: err}
+	}
+}
+
+// WaitForLiveTipCmd blocks until the next tip arrives on updates and returns
+// it as a tui.LiveTipMsg. Connection state changes are only logged. It
+// returns nil once updates is closed, or when updates is nil.
+func WaitForLiveTipCmd(updates <-chan tips.StreamUpdate) tea.Cmd {
+	if updates == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		for u := range updates {
+			if u.Tip != nil {
+				return tui.LiveTipMsg{Tip: *u.Tip}
+			}
+			if u.State == remote.StreamDisconnected {
+				log.Printf("tips stream disconnected, retrying in %s: %v", u.RetryIn, u.Err)
+			}
+		}
+		return nil
+	}
+}
+
+// tipTicker remembers the latest live tip for display under a view.
+type tipTicker struct {
+	tip tips.Tip
+	at  time.Time
+}
+
+func (t *tipTicker) set(tip tips.Tip) {
+	t.tip = tip
+	t.at = time.Now()
+}
+
+// view renders the current tip, or "" once it has gone stale.
+func (t *tipTicker) view(style lipgloss.Style, width int) string {
+	if t.tip.Message == "" || time.Since(t.at) > tipTickerDuration {
+		return ""
+	}
+	return style.Width(width).Render(tipPrefix + t.tip.Message)
+}
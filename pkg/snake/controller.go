@@ -16,6 +16,9 @@ type GameController interface {
 	GetTickInterval() time.Duration
 	GetDefaultTickInterval() time.Duration
 
+	// Events returns the bus the game publishes its Event values on.
+	Events() *EventBus
+
 	Tick()
 	TogglePause()
 	ChangeDirection(Direction)
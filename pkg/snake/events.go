@@ -0,0 +1,170 @@
+package snake
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Event is a typed notification published by a game mode whenever something
+// noteworthy happens during a Tick (or a pause toggle). Every event has a
+// human-readable String so subscribers can surface it without a type switch.
+type Event interface {
+	fmt.Stringer
+	isEvent()
+}
+
+// DeathCause describes why a snake died.
+type DeathCause int
+
+const (
+	CauseWall DeathCause = iota
+	CauseSelf
+	CauseBody   // ran into another snake's body
+	CauseHeadOn // two heads stepped onto the same cell
+	CauseBomb
+	CauseDisconnect // the player left and did not come back in time
+)
+
+var deathCauseToStrMap = map[DeathCause]string{
+	CauseWall:       "hit a wall",
+	CauseSelf:       "ran into itself",
+	CauseBody:       "ran into another snake",
+	CauseHeadOn:     "head-on collision",
+	CauseBomb:       "stepped on a bomb",
+	CauseDisconnect: "disconnected",
+}
+
+func (c DeathCause) String() string {
+	return deathCauseToStrMap[c]
+}
+
+// FoodEaten is published when a snake eats a food pellet.
+type FoodEaten struct {
+	Player int    // player index; 0 in single-player modes
+	Name   string // player name, empty in single-player modes
+	At     Point
+	Points int
+}
+
+// LevelUp is published when the game level rises.
+type LevelUp struct {
+	Level int
+}
+
+// SnakeDied is published when a player's snake dies.
+type SnakeDied struct {
+	Player int    // player index; 0 in single-player modes
+	Name   string // player name, empty in single-player modes
+	Cause  DeathCause
+}
+
+// BombArmed is published when a crazy-mode bomb leaves its warning phase and
+// becomes lethal.
+type BombArmed struct {
+	At Point
+}
+
+// BombMoved is published when an expired bomb relocates and starts warning
+// again at its new position.
+type BombMoved struct {
+	From Point
+	To   Point
+}
+
+// AIKilled is published when the AI opponent's snake dies.
+type AIKilled struct {
+	Cause DeathCause
+}
+
+// PauseToggled is published whenever the game is paused or resumed.
+type PauseToggled struct {
+	Paused bool
+}
+
+func (FoodEaten) isEvent()    {}
+func (LevelUp) isEvent()      {}
+func (SnakeDied) isEvent()    {}
+func (BombArmed) isEvent()    {}
+func (BombMoved) isEvent()    {}
+func (AIKilled) isEvent()     {}
+func (PauseToggled) isEvent() {}
+
+func (e FoodEaten) String() string {
+	if e.Name != "" {
+		return fmt.Sprintf("%s ate food (+%d)", e.Name, e.Points)
+	}
+	return fmt.Sprintf("Food eaten (+%d)", e.Points)
+}
+
+func (e LevelUp) String() string {
+	return fmt.Sprintf("Level up! Now level %d", e.Level)
+}
+
+func (e SnakeDied) String() string {
+	if e.Name != "" {
+		return fmt.Sprintf("%s died (%s)", e.Name, e.Cause)
+	}
+	return fmt.Sprintf("You died (%s)", e.Cause)
+}
+
+func (e BombArmed) String() string {
+	return fmt.Sprintf("Bomb armed at %d,%d", e.At.X, e.At.Y)
+}
+
+func (e BombMoved) String() string {
+	return fmt.Sprintf("Bomb moved to %d,%d", e.To.X, e.To.Y)
+}
+
+func (e AIKilled) String() string {
+	return fmt.Sprintf("AI died (%s)", e.Cause)
+}
+
+func (e PauseToggled) String() string {
+	if e.Paused {
+		return "Paused"
+	}
+	return "Resumed"
+}
+
+// EventBus fans published events out to every current subscriber. Handlers
+// are invoked synchronously on the publishing goroutine (the one calling
+// Tick), so they must be quick and must not call back into the game.
+type EventBus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   map[int]func(Event)
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[int]func(Event))}
+}
+
+// Subscribe registers fn for every subsequent event and returns a function
+// that removes the subscription again.
+func (b *EventBus) Subscribe(fn func(Event)) (unsubscribe func()) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = fn
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.subs, id)
+		b.mu.Unlock()
+	}
+}
+
+// Publish delivers e to every subscriber.
+func (b *EventBus) Publish(e Event) {
+	b.mu.RLock()
+	handlers := make([]func(Event), 0, len(b.subs))
+	for _, fn := range b.subs {
+		handlers = append(handlers, fn)
+	}
+	b.mu.RUnlock()
+
+	for _, fn := range handlers {
+		fn(e)
+	}
+}
@@ -0,0 +1,27 @@
+package snake
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GameMode identifies which ruleset was played.
+type GameMode string
+
+const (
+	GameModeNormal GameMode = "normal"
+	GameModeCrazy  GameMode = "crazy"
+	GameModeAI     GameMode = "AI"
+)
+
+var gameModes = []GameMode{GameModeNormal, GameModeCrazy, GameModeAI}
+
+// ParseGameMode maps a case-insensitive mode name (e.g. "ai") to its GameMode.
+func ParseGameMode(s string) (GameMode, error) {
+	for _, m := range gameModes {
+		if strings.EqualFold(s, string(m)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid game mode '%s'", s)
+}
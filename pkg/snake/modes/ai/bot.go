@@ -0,0 +1,106 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+)
+
+// Difficulty sets how well a Bot plays.
+type Difficulty int
+
+const (
+	// Easy bots blunder often, never hunt other snakes and rarely take risks.
+	Easy Difficulty = iota
+	// Normal bots play like the opponent in the 1v1 AI mode.
+	Normal
+	// Hard bots almost never blunder and go for kills more often.
+	Hard
+)
+
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "easy"
+	case Hard:
+		return "hard"
+	default:
+		return "normal"
+	}
+}
+
+// Next returns the following difficulty, wrapping from Hard back to Easy.
+func (d Difficulty) Next() Difficulty {
+	return (d + 1) % (Hard + 1)
+}
+
+// tuning is how a difficulty adjusts the pathfinder.
+type tuning struct {
+	mistakes   float64 // scales the level-based mistake probability
+	aggression float64 // probability per tick of an aggressive play
+	intercept  bool    // whether the bot tries to cut off other snakes
+}
+
+func (d Difficulty) tuning() tuning {
+	switch d {
+	case Easy:
+		return tuning{mistakes: 4, aggression: 0.05, intercept: false}
+	case Hard:
+		return tuning{mistakes: 0.25, aggression: 0.35, intercept: true}
+	default:
+		return tuning{mistakes: 1, aggression: aggressionChance, intercept: true}
+	}
+}
+
+// Opponent is another snake's head as seen by a Bot.
+type Opponent struct {
+	Head      snake.Point
+	Direction snake.Direction
+}
+
+// Board is what a Bot sees of the game on one tick. Cells outside Rows × Cols
+// are treated as walls, so on wrapping boards bots simply avoid the edges.
+type Board struct {
+	Rows, Cols int
+
+	// Occupied holds every cell that is fatal to move into: all snake bodies
+	// except the bot's own head, plus any hazards.
+	Occupied map[snake.Point]any
+
+	Food      []snake.Point
+	Opponents []Opponent // live snakes other than the bot's own
+	Level     int
+}
+
+// Bot steers one computer-controlled snake. It keeps state between ticks, so
+// every snake needs its own Bot.
+type Bot struct {
+	difficulty Difficulty
+	state      *aiState
+}
+
+// NewBot returns a bot of the given difficulty drawing from rng.
+func NewBot(d Difficulty, rng *rand.Rand) *Bot {
+	return &Bot{difficulty: d, state: newAIState(rng)}
+}
+
+func (b *Bot) Difficulty() Difficulty { return b.difficulty }
+
+// NextDirection decides where the snake with the given body, currently moving
+// in current, should move next.
+func (b *Bot) NextDirection(body []snake.Point, current snake.Direction, board Board) snake.Direction {
+	if len(body) == 0 {
+		return current
+	}
+	return nextDirection(
+		grid{rows: board.Rows, cols: board.Cols},
+		body,
+		current,
+		board.Food,
+		board.Opponents,
+		board.Occupied,
+		board.Level,
+		b.difficulty.tuning(),
+		b.state,
+	)
+}
@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"math/rand"
+
 	"github.com/HilthonTT/gosnake/internal/data"
 	"github.com/HilthonTT/gosnake/pkg/snake"
 )
@@ -31,16 +33,20 @@ type Game struct {
 	aiDir   snake.Direction
 	aiScore *snake.Scoring
 	aiAlive bool
-	aiSt    *aiState
+	aiBot   *Bot
 
 	food     *snake.Point
 	paused   bool
 	gameOver bool
 
-	repo *data.LeaderboardRepository
+	repo   *data.LeaderboardRepository
+	events *snake.EventBus
+	rng    *rand.Rand
 }
 
-func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
+func NewGame(repo *data.LeaderboardRepository, opts ...snake.Option) (*Game, error) {
+	rng := snake.NewOptions(opts...).Rand()
+
 	matrix := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
 
 	playerScoring, err := snake.NewScoring(1, 10, 100, true, false)
@@ -63,7 +69,7 @@ func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
 	}
 
 	allOccupied := append(playerStart, aiStart...)
-	food := snake.NewFood(allOccupied)
+	food := snake.NewFood(rng, allOccupied)
 
 	g := &Game{
 		matrix:      matrix,
@@ -76,9 +82,11 @@ func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
 		aiDir:       snake.Left,
 		aiScore:     aiScoring,
 		aiAlive:     true,
-		aiSt:        newAIState(),
+		aiBot:       NewBot(Normal, rng),
 		food:        food,
 		repo:        repo,
+		events:      snake.NewEventBus(),
+		rng:         rng,
 	}
 
 	g.render()
@@ -108,6 +116,7 @@ func (g *Game) TogglePause() {
 		return
 	}
 	g.paused = !g.paused
+	g.events.Publish(snake.PauseToggled{Paused: g.paused})
 }
 
 // Tick advances both snakes by one step simultaneously.
@@ -119,17 +128,14 @@ func (g *Game) Tick() {
 	// AI picks its next direction using the improved pathfinder.
 	if g.aiAlive {
 		occupied := occupiedSet(g.playerBody, g.aiBody)
-		g.aiDir = nextDirection(
-			g.aiBody[0],
-			*g.food,
-			g.playerBody[0],
-			g.playerDir,
-			g.aiDir,
-			occupied,
-			g.aiBody,
-			g.playerScore.Level(),
-			g.aiSt,
-		)
+		g.aiDir = g.aiBot.NextDirection(g.aiBody, g.aiDir, Board{
+			Rows:      snake.DefaultRows,
+			Cols:      snake.DefaultCols,
+			Occupied:  occupied,
+			Food:      []snake.Point{*g.food},
+			Opponents: []Opponent{{Head: g.playerBody[0], Direction: g.playerDir}},
+			Level:     g.playerScore.Level(),
+		})
 	}
 
 	// Compute next head positions.
@@ -147,24 +153,31 @@ func (g *Game) Tick() {
 		g.playerAlive = false
 		g.aiAlive = false
 		g.gameOver = true
+		g.events.Publish(snake.SnakeDied{Cause: snake.CauseHeadOn})
+		g.events.Publish(snake.AIKilled{Cause: snake.CauseHeadOn})
 		g.render()
 		return
 	}
 
 	// Move player.
 	if g.playerAlive {
-		if !g.matrix.InBounds(playerNext) || g.isSelfCollision(playerNext, g.playerBody) {
-			g.playerAlive = false
-		} else if g.isBodyCollision(playerNext, g.aiBody) {
+		if cause, dead := g.collisionCause(playerNext, g.playerBody, g.aiBody); dead {
 			g.playerAlive = false
+			g.events.Publish(snake.SnakeDied{Cause: cause})
 		}
 
 		if g.playerAlive {
 			ateFood := playerNext == *g.food
 			g.playerBody = append([]snake.Point{playerNext}, g.playerBody...)
 			if ateFood {
+				prevLevel := g.playerScore.Level()
 				g.playerScore.AddPoints(10)
-				g.food = snake.NewFood(append(g.playerBody, g.aiBody...))
+				g.food = snake.NewFood(g.rng, append(g.playerBody, g.aiBody...))
+
+				g.events.Publish(snake.FoodEaten{At: playerNext, Points: 10})
+				if g.playerScore.Level() > prevLevel {
+					g.events.Publish(snake.LevelUp{Level: g.playerScore.Level()})
+				}
 			} else {
 				g.playerBody = g.playerBody[:len(g.playerBody)-1]
 			}
@@ -173,10 +186,9 @@ func (g *Game) Tick() {
 
 	// Move AI.
 	if g.aiAlive {
-		if !g.matrix.InBounds(aiNext) || g.isSelfCollision(aiNext, g.aiBody) {
-			g.aiAlive = false
-		} else if g.isBodyCollision(aiNext, g.playerBody) {
+		if cause, dead := g.collisionCause(aiNext, g.aiBody, g.playerBody); dead {
 			g.aiAlive = false
+			g.events.Publish(snake.AIKilled{Cause: cause})
 		}
 
 		if g.aiAlive {
@@ -184,7 +196,7 @@ func (g *Game) Tick() {
 			g.aiBody = append([]snake.Point{aiNext}, g.aiBody...)
 			if ateFood {
 				g.aiScore.AddPoints(10)
-				g.food = snake.NewFood(append(g.playerBody, g.aiBody...))
+				g.food = snake.NewFood(g.rng, append(g.playerBody, g.aiBody...))
 			} else {
 				g.aiBody = g.aiBody[:len(g.aiBody)-1]
 			}
@@ -204,6 +216,20 @@ func (g *Game) SaveScore(name string) error {
 	return err
 }
 
+// collisionCause reports whether a snake whose body is own would die by
+// stepping onto next, and why.
+func (g *Game) collisionCause(next snake.Point, own, other []snake.Point) (snake.DeathCause, bool) {
+	switch {
+	case !g.matrix.InBounds(next):
+		return snake.CauseWall, true
+	case g.isSelfCollision(next, own):
+		return snake.CauseSelf, true
+	case g.isBodyCollision(next, other):
+		return snake.CauseBody, true
+	}
+	return 0, false
+}
+
 func (g *Game) isSelfCollision(p snake.Point, body []snake.Point) bool {
 	for _, s := range body {
 		if s == p {
@@ -264,7 +290,7 @@ func (g *Game) Snapshot() map[string]any {
 		"aiDir":          g.aiDir,
 		"aiLen":          len(g.aiBody),
 		"aiAlive":        g.aiAlive,
-		"aiTailChaseTks": g.aiSt.tailChaseTicks,
+		"aiTailChaseTks": g.aiBot.state.tailChaseTicks,
 		"food":           g.food,
 		"paused":         g.paused,
 		"gameOver":       g.gameOver,
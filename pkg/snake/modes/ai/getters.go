@@ -16,6 +16,7 @@ func (g *Game) SnakeLength() int                      { return len(g.playerBody)
 func (g *Game) Food() *snake.Point                    { return g.food }
 func (g *Game) GetTickInterval() time.Duration        { return snake.GetTickInterval(g.playerScore.Level()) }
 func (g *Game) GetDefaultTickInterval() time.Duration { return snake.GetTickInterval(1) }
+func (g *Game) Events() *snake.EventBus               { return g.events }
 
 func (g *Game) AIScore() int        { return g.aiScore.Total() }
 func (g *Game) AISnakeLength() int  { return len(g.aiBody) }
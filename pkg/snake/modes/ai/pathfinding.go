@@ -25,7 +25,7 @@ const (
 	// predicts when computing an intercept target.
 	interceptLookAhead = 3
 
-	// aggressionChance is the probability per tick that the AI enters
+	// aggressionChance is the probability per tick that the Normal AI enters
 	// "aggressive mode" — it drops safety checks and goes straight for the
 	// intercept or food regardless of dead-end risk. This is what makes the
 	// AI killable: aggressive plays can backfire.
@@ -50,15 +50,27 @@ const (
 	wallOffRange = 6
 )
 
-// aiState tracks per-tick mutable decisions so the caller (Game.Tick) can
-// persist it across ticks. Currently tracks consecutive tail-chase ticks.
+// aiState tracks per-tick mutable decisions so the caller can persist it
+// across ticks: consecutive tail-chase ticks, and the game's random source so
+// the AI's choices replay exactly.
 type aiState struct {
 	tailChaseTicks int
+	rng            *rand.Rand
 }
 
-// newAIState returns a zero-valued state for a fresh game.
-func newAIState() *aiState {
-	return &aiState{}
+// newAIState returns the state for a fresh game drawing from rng.
+func newAIState(rng *rand.Rand) *aiState {
+	return &aiState{rng: rng}
+}
+
+// grid is the size of the board the pathfinder searches. Cells outside it are
+// walls.
+type grid struct {
+	rows, cols int
+}
+
+func (g grid) inBounds(p snake.Point) bool {
+	return p.X >= 0 && p.X < g.cols && p.Y >= 0 && p.Y < g.rows
 }
 
 // nextDirection decides where the AI moves next.
@@ -68,61 +80,71 @@ func newAIState() *aiState {
 // safety checks to go for kills). Aggressive plays can trap the AI in dead
 // ends, which is how the player wins.
 //
+// With several opponents the AI hunts the one whose head is nearest; with
+// several food pellets it chases the nearest.
+//
 // Priority order:
 //  1. Random mistake  — level-scaled probability of a random safe move.
-//  2. Aggressive play — with aggressionChance probability, skip safety:
-//     a. Wall-off     — if very close, move directly toward the player.
-//     b. Intercept    — BFS to predicted player position (no flood-fill).
+//  2. Aggressive play — with the tuning's aggression probability, skip safety:
+//     a. Wall-off     — if very close, move directly toward the opponent.
+//     b. Intercept    — BFS to predicted opponent position (no flood-fill).
 //     c. Raw food     — BFS to food (no flood-fill).
-//  3. Safe intercept  — flood-fill validated path to predicted player pos.
+//  3. Safe intercept  — flood-fill validated path to predicted opponent pos.
 //  4. Safe food chase — flood-fill validated path to food.
 //  5. Tail chase      — follow own tail (capped by tailChaseLimit).
 //  6. Forced commit   — if tail-chasing too long, BFS to food unsafely.
 //  7. Largest space   — pick the most open adjacent cell.
 //  8. Current dir     — everything is fatal; crash.
 func nextDirection(
-	head snake.Point,
-	food snake.Point,
-	playerHead snake.Point,
-	playerDir snake.Direction,
+	g grid,
+	body []snake.Point,
 	current snake.Direction,
+	food []snake.Point,
+	opponents []Opponent,
 	occupied map[snake.Point]any,
-	aiBody []snake.Point,
 	level int,
+	t tuning,
 	state *aiState,
 ) snake.Direction {
-	bodyLen := len(aiBody)
+	head := body[0]
+	bodyLen := len(body)
 
 	// 1. Level-scaled random mistake.
 	chance := mistakeBase - mistakeDecay*float64(level-1)
 	if chance < mistakeFloor {
 		chance = mistakeFloor
 	}
-	if rand.Float64() < chance {
-		if dir, ok := randomSafeDirection(head, current, occupied); ok {
+	if state.rng.Float64() < chance*t.mistakes {
+		if dir, ok := g.randomSafeDirection(state.rng, head, current, occupied); ok {
 			state.tailChaseTicks = 0
 			return dir
 		}
 	}
 
-	aggressive := rand.Float64() < aggressionChance
-	dist := manhattanDist(head, playerHead)
+	aggressive := state.rng.Float64() < t.aggression
+
+	target, hasFood := nearestPoint(head, food)
+	prey, dist := nearestOpponent(head, opponents)
+	if !t.intercept {
+		dist = -1
+	}
+	hunting := dist >= 0 && dist <= interceptRange
 
 	// 2. Aggressive play — skip flood-fill safety, commit to risky paths.
 	if aggressive {
-		// 2a. Wall-off: very close → move directly toward the player's head.
-		if dist <= wallOffRange {
-			if dir, ok := bfs(head, playerHead, occupied); ok {
+		// 2a. Wall-off: very close → move directly toward the opponent's head.
+		if dist >= 0 && dist <= wallOffRange {
+			if dir, ok := g.bfs(head, prey.Head, occupied); ok {
 				state.tailChaseTicks = 0
 				return dir
 			}
 		}
 
 		// 2b. Intercept without safety check.
-		if dist <= interceptRange {
-			target := predictPlayerPos(playerHead, playerDir, interceptLookAhead, occupied)
-			if target != playerHead {
-				if dir, ok := bfs(head, target, occupied); ok {
+		if hunting {
+			predicted := g.predictPlayerPos(prey.Head, prey.Direction, interceptLookAhead, occupied)
+			if predicted != prey.Head {
+				if dir, ok := g.bfs(head, predicted, occupied); ok {
 					state.tailChaseTicks = 0
 					return dir
 				}
@@ -130,19 +152,21 @@ func nextDirection(
 		}
 
 		// 2c. Chase food without safety check.
-		if dir, ok := bfs(head, food, occupied); ok {
-			state.tailChaseTicks = 0
-			return dir
+		if hasFood {
+			if dir, ok := g.bfs(head, target, occupied); ok {
+				state.tailChaseTicks = 0
+				return dir
+			}
 		}
 	}
 
 	minSafe := int(float64(bodyLen) * safetyMarginNormal)
 
 	// 3. Safe intercept.
-	if dist <= interceptRange {
-		target := predictPlayerPos(playerHead, playerDir, interceptLookAhead, occupied)
-		if target != playerHead {
-			if dir, ok := safeBFS(head, target, occupied, minSafe); ok {
+	if hunting {
+		predicted := g.predictPlayerPos(prey.Head, prey.Direction, interceptLookAhead, occupied)
+		if predicted != prey.Head {
+			if dir, ok := g.safeBFS(head, predicted, occupied, minSafe); ok {
 				state.tailChaseTicks = 0
 				return dir
 			}
@@ -150,15 +174,17 @@ func nextDirection(
 	}
 
 	// 4. Safe food chase.
-	if dir, ok := safeBFS(head, food, occupied, minSafe); ok {
-		state.tailChaseTicks = 0
-		return dir
+	if hasFood {
+		if dir, ok := g.safeBFS(head, target, occupied, minSafe); ok {
+			state.tailChaseTicks = 0
+			return dir
+		}
 	}
 
 	// 5. Tail chase (capped).
 	if state.tailChaseTicks < tailChaseLimit && bodyLen > 1 {
-		tail := aiBody[bodyLen-1]
-		if dir, ok := bfs(head, tail, occupied); ok {
+		tail := body[bodyLen-1]
+		if dir, ok := g.bfs(head, tail, occupied); ok {
 			state.tailChaseTicks++
 			return dir
 		}
@@ -167,29 +193,55 @@ func nextDirection(
 	// 6. Forced commit — tail-chase limit exceeded or no tail path.
 	//    Use a very low safety threshold so the AI actually moves toward food
 	//    instead of looping forever.
-	minAggressive := int(float64(bodyLen) * safetyMarginAggressive)
-	if dir, ok := safeBFS(head, food, occupied, minAggressive); ok {
-		state.tailChaseTicks = 0
-		return dir
-	}
-	// Last resort: raw BFS to food, no safety at all.
-	if dir, ok := bfs(head, food, occupied); ok {
-		state.tailChaseTicks = 0
-		return dir
+	if hasFood {
+		minAggressive := int(float64(bodyLen) * safetyMarginAggressive)
+		if dir, ok := g.safeBFS(head, target, occupied, minAggressive); ok {
+			state.tailChaseTicks = 0
+			return dir
+		}
+		// Last resort: raw BFS to food, no safety at all.
+		if dir, ok := g.bfs(head, target, occupied); ok {
+			state.tailChaseTicks = 0
+			return dir
+		}
 	}
 
 	// 7. Largest reachable area.
-	if dir, ok := largestFloodDir(head, current, occupied); ok {
+	if dir, ok := g.largestFloodDir(head, current, occupied); ok {
 		return dir
 	}
 
 	return current
 }
 
+// nearestPoint returns the point in pts closest to p, or false if pts is
+// empty.
+func nearestPoint(p snake.Point, pts []snake.Point) (snake.Point, bool) {
+	best, bestDist := snake.Point{}, -1
+	for _, q := range pts {
+		if d := manhattanDist(p, q); bestDist < 0 || d < bestDist {
+			best, bestDist = q, d
+		}
+	}
+	return best, bestDist >= 0
+}
+
+// nearestOpponent returns the opponent whose head is closest to p and its
+// distance, or a distance of -1 if there are none.
+func nearestOpponent(p snake.Point, opponents []Opponent) (Opponent, int) {
+	best, bestDist := Opponent{}, -1
+	for _, o := range opponents {
+		if d := manhattanDist(p, o.Head); bestDist < 0 || d < bestDist {
+			best, bestDist = o, d
+		}
+	}
+	return best, bestDist
+}
+
 // safeBFS finds the shortest path from src to dst and then verifies the first
 // step leaves at least minSafe reachable cells (flood-fill).
-func safeBFS(src, dst snake.Point, occupied map[snake.Point]any, minSafe int) (snake.Direction, bool) {
-	dir, ok := bfs(src, dst, occupied)
+func (g grid) safeBFS(src, dst snake.Point, occupied map[snake.Point]any, minSafe int) (snake.Direction, bool) {
+	dir, ok := g.bfs(src, dst, occupied)
 	if !ok {
 		return 0, false
 	}
@@ -198,7 +250,7 @@ func safeBFS(src, dst snake.Point, occupied map[snake.Point]any, minSafe int) (s
 	simOccupied := copyOccupied(occupied)
 	simOccupied[src] = struct{}{}
 
-	reachable := floodFill(next, simOccupied)
+	reachable := g.floodFill(next, simOccupied)
 	if reachable >= minSafe {
 		return dir, true
 	}
@@ -208,12 +260,11 @@ func safeBFS(src, dst snake.Point, occupied map[snake.Point]any, minSafe int) (s
 
 // largestFloodDir evaluates all four cardinal directions and returns the one
 // that leads to the largest connected region of free space.
-func largestFloodDir(
+func (g grid) largestFloodDir(
 	head snake.Point,
 	current snake.Direction,
 	occupied map[snake.Point]any,
 ) (snake.Direction, bool) {
-	bounds := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
 	dirs := []snake.Direction{snake.Up, snake.Down, snake.Left, snake.Right}
 
 	bestDir := current
@@ -222,7 +273,7 @@ func largestFloodDir(
 
 	for _, d := range dirs {
 		nb := step(head, d)
-		if !bounds.InBounds(nb) {
+		if !g.inBounds(nb) {
 			continue
 		}
 		if _, blocked := occupied[nb]; blocked {
@@ -232,7 +283,7 @@ func largestFloodDir(
 		simOccupied := copyOccupied(occupied)
 		simOccupied[head] = struct{}{}
 
-		count := floodFill(nb, simOccupied)
+		count := g.floodFill(nb, simOccupied)
 		if count > bestCount {
 			bestCount = count
 			bestDir = d
@@ -245,10 +296,8 @@ func largestFloodDir(
 
 // floodFill counts how many cells are reachable from start without crossing
 // any point in occupied or leaving the board.
-func floodFill(start snake.Point, occupied map[snake.Point]any) int {
-	bounds := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
-
-	if !bounds.InBounds(start) {
+func (g grid) floodFill(start snake.Point, occupied map[snake.Point]any) int {
+	if !g.inBounds(start) {
 		return 0
 	}
 	if _, blocked := occupied[start]; blocked {
@@ -267,7 +316,7 @@ func floodFill(start snake.Point, occupied map[snake.Point]any) int {
 
 		for _, d := range []snake.Direction{snake.Up, snake.Down, snake.Left, snake.Right} {
 			nb := step(cur, d)
-			if !bounds.InBounds(nb) {
+			if !g.inBounds(nb) {
 				continue
 			}
 			if _, seen := visited[nb]; seen {
@@ -286,18 +335,17 @@ func floodFill(start snake.Point, occupied map[snake.Point]any) int {
 
 // predictPlayerPos walks the player's current direction forward up to n steps,
 // stopping at walls or occupied cells.
-func predictPlayerPos(
+func (g grid) predictPlayerPos(
 	playerHead snake.Point,
 	playerDir snake.Direction,
 	n int,
 	occupied map[snake.Point]any,
 ) snake.Point {
-	bounds := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
 	pos := playerHead
 
 	for i := 0; i < n; i++ {
 		next := step(pos, playerDir)
-		if !bounds.InBounds(next) {
+		if !g.inBounds(next) {
 			break
 		}
 		if _, blocked := occupied[next]; blocked {
@@ -314,20 +362,18 @@ func predictPlayerPos(
 
 // bfs performs a breadth-first search from src to dst, treating every point
 // in occupied as a wall.
-func bfs(src, dst snake.Point, occupied map[snake.Point]any) (snake.Direction, bool) {
+func (g grid) bfs(src, dst snake.Point, occupied map[snake.Point]any) (snake.Direction, bool) {
 	type state struct {
 		pt       snake.Point
 		firstDir snake.Direction
 	}
 
-	bounds := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
-
 	visited := map[snake.Point]struct{}{src: {}}
 	queue := []state{}
 
 	for _, dir := range []snake.Direction{snake.Up, snake.Down, snake.Left, snake.Right} {
 		nb := step(src, dir)
-		if !bounds.InBounds(nb) {
+		if !g.inBounds(nb) {
 			continue
 		}
 		if _, blocked := occupied[nb]; blocked {
@@ -348,7 +394,7 @@ func bfs(src, dst snake.Point, occupied map[snake.Point]any) (snake.Direction, b
 
 		for _, dir := range []snake.Direction{snake.Up, snake.Down, snake.Left, snake.Right} {
 			nb := step(cur.pt, dir)
-			if !bounds.InBounds(nb) {
+			if !g.inBounds(nb) {
 				continue
 			}
 			if _, seen := visited[nb]; seen {
@@ -411,18 +457,18 @@ func manhattanDist(a, b snake.Point) int {
 }
 
 // randomSafeDirection picks a uniformly random safe direction.
-func randomSafeDirection(
+func (g grid) randomSafeDirection(
+	rng *rand.Rand,
 	head snake.Point,
 	current snake.Direction,
 	occupied map[snake.Point]any,
 ) (snake.Direction, bool) {
 	dirs := []snake.Direction{snake.Up, snake.Down, snake.Left, snake.Right}
-	rand.Shuffle(len(dirs), func(i, j int) { dirs[i], dirs[j] = dirs[j], dirs[i] })
+	rng.Shuffle(len(dirs), func(i, j int) { dirs[i], dirs[j] = dirs[j], dirs[i] })
 
-	bounds := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
 	for _, d := range dirs {
 		nb := step(head, d)
-		if !bounds.InBounds(nb) {
+		if !g.inBounds(nb) {
 			continue
 		}
 		if _, blocked := occupied[nb]; blocked {
@@ -34,37 +34,45 @@ const (
 type Bomb struct {
 	Point     snake.Point
 	State     BombState
-	ChangesAt time.Time // wall-clock deadline for the next state transition
+	ChangesAt time.Duration // game time of the next state transition
 }
 
-// newBomb creates a bomb in the warning phase at a random unoccupied position.
-// occupied should contain all points that must not overlap (snake, food, other
-// bombs currently active)
-func newBomb(occupied []snake.Point) *Bomb {
-	p := randomFreePoint(occupied)
+// NewBomb creates a bomb in the warning phase at a random unoccupied position
+// on a rows×cols board. occupied should contain all points that must not
+// overlap (snake, food, other bombs currently active)
+func NewBomb(rng *rand.Rand, rows, cols int, occupied []snake.Point, now time.Duration) *Bomb {
+	p := randomFreePoint(rng, rows, cols, occupied)
 	return &Bomb{
 		Point:     p,
 		State:     BombStateWarning,
-		ChangesAt: time.Now().Add(BombWarningDuration),
+		ChangesAt: now + BombWarningDuration,
 	}
 }
 
-func (b *Bomb) update(occupied []snake.Point) {
-	if time.Now().Before(b.ChangesAt) {
-		return
+// Update advances the bomb's state machine once its deadline has passed at
+// game time now. It returns the event describing the transition, or nil if
+// nothing changed.
+func (b *Bomb) Update(rng *rand.Rand, rows, cols int, occupied []snake.Point, now time.Duration) snake.Event {
+	if now < b.ChangesAt {
+		return nil
 	}
 
 	switch b.State {
 	case BombStateWarning:
 		b.State = BombStateActive
-		b.ChangesAt = time.Now().Add(BombActiveDuration)
+		b.ChangesAt = now + BombActiveDuration
+		return snake.BombArmed{At: b.Point}
 
 	case BombStateActive:
 		// Active period is over — pick a new spot and start warning again.
-		b.Point = randomFreePoint(occupied)
+		from := b.Point
+		b.Point = randomFreePoint(rng, rows, cols, occupied)
 		b.State = BombStateWarning
-		b.ChangesAt = time.Now().Add(BombWarningDuration)
+		b.ChangesAt = now + BombWarningDuration
+		return snake.BombMoved{From: from, To: b.Point}
 	}
+
+	return nil
 }
 
 // IsActive returns true while the bomb is lethal.
@@ -84,18 +92,18 @@ func (b *Bomb) ShouldRenderWarning() bool {
 	return (time.Now().UnixMilli()/BombBlinkPeriodMs)%2 == 0
 }
 
-// bombCountForLevel returns how many bombs should be active at a given level.
+// BombCountForLevel returns how many bombs should be active at a given level.
 // Starts at 3 for level 1 and grows by 1 per level.
-func bombCountForLevel(level int) int {
+func BombCountForLevel(level int) int {
 	return level + 2 // level 1 -> 3, level 2 -> 4, …
 }
 
 // randomFreePoint picks a random board cell that does not appear in occupied.
-func randomFreePoint(occupied []snake.Point) snake.Point {
+func randomFreePoint(rng *rand.Rand, rows, cols int, occupied []snake.Point) snake.Point {
 	for {
 		p := snake.Point{
-			X: rand.Intn(snake.DefaultCols),
-			Y: rand.Intn(snake.DefaultRows),
+			X: rng.Intn(cols),
+			Y: rng.Intn(rows),
 		}
 		if !pointIn(occupied, p) {
 			return p
@@ -1,7 +1,9 @@
 package crazy
 
 import (
+	"math/rand"
 	"slices"
+	"time"
 
 	"github.com/HilthonTT/gosnake/internal/data"
 	"github.com/HilthonTT/gosnake/pkg/snake"
@@ -25,9 +27,17 @@ type Game struct {
 	gameOver  bool
 	paused    bool
 	repo      *data.LeaderboardRepository
+	events    *snake.EventBus
+	rng       *rand.Rand
+
+	// clock is the game time played so far. Bomb timers run on it rather
+	// than the wall clock, so they stop while paused and replay exactly.
+	clock time.Duration
 }
 
-func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
+func NewGame(repo *data.LeaderboardRepository, opts ...snake.Option) (*Game, error) {
+	rng := snake.NewOptions(opts...).Rand()
+
 	matrix := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
 
 	scoring, err := snake.NewScoring(1, 10, 100, true, false)
@@ -39,7 +49,7 @@ func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
 		{X: snake.DefaultCols / 2, Y: snake.DefaultRows / 2},
 	}
 
-	food := snake.NewFood(initialSnake)
+	food := snake.NewFood(rng, initialSnake)
 
 	g := &Game{
 		matrix:    matrix,
@@ -49,6 +59,8 @@ func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
 		nextDir:   snake.Right,
 		scoring:   scoring,
 		repo:      repo,
+		events:    snake.NewEventBus(),
+		rng:       rng,
 	}
 
 	// Spawn the initial set of bombs for level 1.
@@ -80,6 +92,7 @@ func (g *Game) TogglePause() {
 		return
 	}
 	g.paused = !g.paused
+	g.events.Publish(snake.PauseToggled{Paused: g.paused})
 }
 
 // Tick advances the game by one step.
@@ -88,6 +101,8 @@ func (g *Game) Tick() {
 		return
 	}
 
+	g.clock += g.GetTickInterval()
+
 	// Update bomb lifecycles
 	g.updateBombs()
 
@@ -113,19 +128,19 @@ func (g *Game) Tick() {
 
 	// Wall collision.
 	if !g.matrix.InBounds(next) {
-		g.gameOver = true
+		g.triggerGameOver(snake.CauseWall)
 		return
 	}
 
 	// Self collision.
 	if g.isSelfCollision(next) {
-		g.gameOver = true
+		g.triggerGameOver(snake.CauseSelf)
 		return
 	}
 
 	// Active bomb collision.
 	if g.isActiveBombCollision(next) {
-		g.gameOver = true
+		g.triggerGameOver(snake.CauseBomb)
 		return
 	}
 
@@ -135,8 +150,14 @@ func (g *Game) Tick() {
 	g.snakeBody = append([]snake.Point{next}, g.snakeBody...)
 
 	if ateFood {
+		prevLevel := g.scoring.Level()
 		g.scoring.AddPoints(10)
-		g.food = snake.NewFood(g.snakeBody)
+		g.food = snake.NewFood(g.rng, g.snakeBody)
+
+		g.events.Publish(snake.FoodEaten{At: next, Points: 10})
+		if g.scoring.Level() > prevLevel {
+			g.events.Publish(snake.LevelUp{Level: g.scoring.Level()})
+		}
 	} else {
 		g.snakeBody = g.snakeBody[:len(g.snakeBody)-1]
 	}
@@ -150,22 +171,30 @@ func (g *Game) SaveScore(name string) error {
 	return err
 }
 
-// updateBombs advances every bomb's state machine.
+func (g *Game) triggerGameOver(cause snake.DeathCause) {
+	g.gameOver = true
+	g.events.Publish(snake.SnakeDied{Cause: cause})
+}
+
+// updateBombs advances every bomb's state machine and publishes any state
+// transition that happened.
 func (g *Game) updateBombs() {
 	for _, b := range g.bombs {
 		// Build occupied list that excludes this bomb's own point so it can
 		// pick a new location freely when it resets.
 		occupied := g.occupiedExcluding(b.Point)
-		b.update(occupied)
+		if e := b.Update(g.rng, snake.DefaultRows, snake.DefaultCols, occupied, g.clock); e != nil {
+			g.events.Publish(e)
+		}
 	}
 }
 
-// syncBombs ensures the bomb slice contains exactly bombCountForLevel(level)
+// syncBombs ensures the bomb slice contains exactly BombCountForLevel(level)
 // entries, adding new ones (in warning phase) whenever the level rises.
 func (g *Game) syncBombs() {
-	want := bombCountForLevel(g.scoring.Level())
+	want := BombCountForLevel(g.scoring.Level())
 	for len(g.bombs) < want {
-		g.bombs = append(g.bombs, newBomb(g.allOccupied()))
+		g.bombs = append(g.bombs, NewBomb(g.rng, snake.DefaultRows, snake.DefaultCols, g.allOccupied(), g.clock))
 	}
 }
 
@@ -0,0 +1,177 @@
+package multi
+
+import (
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
+)
+
+// Autopilot steers a player's snake for one tick: it heads for the nearest
+// food while avoiding walls, snakes and armed bombs on the very next step.
+// It is a caretaker for snakes whose player has dropped, not an opponent.
+func (g *Game) Autopilot(playerIndex int) {
+	if playerIndex < 0 || playerIndex >= len(g.players) {
+		return
+	}
+	p := g.players[playerIndex]
+	if !p.Alive || g.over {
+		return
+	}
+
+	head := p.Points[0]
+	best, bestDist := p.nextDir, -1
+	for _, d := range []snake.Direction{p.Direction, turnLeft(p.Direction), turnRight(p.Direction)} {
+		next := g.step(head, d)
+		if !g.isSafe(next) {
+			continue
+		}
+		dist := g.foodDistance(next)
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = d, dist
+		}
+	}
+
+	g.ChangeDirection(playerIndex, best)
+}
+
+// Steer lets bot choose a player's direction for one tick. Unlike Autopilot
+// it plays to win, using the same pathfinding as the 1v1 AI mode with every
+// other live snake as an opponent.
+func (g *Game) Steer(playerIndex int, bot *ai.Bot) {
+	if playerIndex < 0 || playerIndex >= len(g.players) {
+		return
+	}
+	p := g.players[playerIndex]
+	if !p.Alive || g.over {
+		return
+	}
+	g.ChangeDirection(playerIndex, bot.NextDirection(p.Points, p.Direction, g.botBoard(p)))
+}
+
+// botBoard is the board as seen by p's bot: every snake cell and armed bomb
+// is a wall, apart from p's own head. Teammates are not hunted.
+func (g *Game) botBoard(p *PlayerSnake) ai.Board {
+	board := ai.Board{
+		Rows:     g.rules.Rows,
+		Cols:     g.rules.Cols,
+		Occupied: make(map[snake.Point]any),
+		Level:    g.Level(),
+	}
+
+	for y, row := range g.matrix {
+		for x, kind := range row {
+			if kind == CellHead || kind == CellBody {
+				board.Occupied[snake.Point{X: x, Y: y}] = struct{}{}
+			}
+		}
+	}
+	delete(board.Occupied, p.Points[0])
+	for _, b := range g.bombs {
+		if b.IsActive() {
+			board.Occupied[b.Point] = struct{}{}
+		}
+	}
+
+	for _, f := range g.food {
+		if f != nil {
+			board.Food = append(board.Food, *f)
+		}
+	}
+	for _, other := range g.players {
+		if other != p && other.Alive && !g.teammates(other, p) {
+			board.Opponents = append(board.Opponents, ai.Opponent{Head: other.Points[0], Direction: other.Direction})
+		}
+	}
+	return board
+}
+
+// Eliminate kills a player's snake outside of normal collisions, for example
+// when the player has left the game.
+func (g *Game) Eliminate(playerIndex int, cause snake.DeathCause) {
+	if playerIndex < 0 || playerIndex >= len(g.players) || g.over {
+		return
+	}
+	p := g.players[playerIndex]
+	if !p.Alive {
+		return
+	}
+
+	p.Alive = false
+	g.events.Publish(snake.SnakeDied{Player: p.Index, Name: p.Name, Cause: cause})
+	g.checkWin()
+	g.render()
+}
+
+// step returns the cell one move from p in direction d, wrapping if the rules
+// allow it.
+func (g *Game) step(p snake.Point, d snake.Direction) snake.Point {
+	switch d {
+	case snake.Up:
+		p.Y--
+	case snake.Down:
+		p.Y++
+	case snake.Left:
+		p.X--
+	case snake.Right:
+		p.X++
+	}
+	if g.rules.Wrap {
+		p = g.wrap(p)
+	}
+	return p
+}
+
+func (g *Game) isSafe(p snake.Point) bool {
+	if !g.matrix.InBounds(p) || g.isActiveBomb(p) {
+		return false
+	}
+	kind := g.matrix.Get(p)
+	return kind != CellHead && kind != CellBody
+}
+
+// foodDistance is the Manhattan distance from p to the nearest food.
+func (g *Game) foodDistance(p snake.Point) int {
+	best := -1
+	for _, f := range g.food {
+		if f == nil {
+			continue
+		}
+		d := abs(f.X-p.X) + abs(f.Y-p.Y)
+		if best < 0 || d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func turnLeft(d snake.Direction) snake.Direction {
+	switch d {
+	case snake.Up:
+		return snake.Left
+	case snake.Left:
+		return snake.Down
+	case snake.Down:
+		return snake.Right
+	default:
+		return snake.Up
+	}
+}
+
+func turnRight(d snake.Direction) snake.Direction {
+	switch d {
+	case snake.Up:
+		return snake.Right
+	case snake.Right:
+		return snake.Down
+	case snake.Down:
+		return snake.Left
+	default:
+		return snake.Up
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
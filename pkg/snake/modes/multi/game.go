@@ -1,13 +1,28 @@
 package multi
 
-import "github.com/HilthonTT/gosnake/pkg/snake"
-
-const MaxPlayers = 3
-
-// HeadCells and BodyCells map a player index to its matrix byte value.
-// Exported so the TUI layer can build its render switch without magic literals.
-var HeadCells = [MaxPlayers]byte{'H', 'A', 'X'}
-var BodyCells = [MaxPlayers]byte{'S', 'B', 'Y'}
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/crazy"
+)
+
+const MaxPlayers = 8
+
+// Cell kinds stored in the matrix. Snake cells only say head or body; which
+// player a snake cell belongs to is kept in the separate owner layer (see
+// Owners), so the encoding doesn't limit the number of players.
+const (
+	CellEmpty       byte = 0
+	CellHead        byte = 'H'
+	CellBody        byte = 'S'
+	CellFood        byte = 'F'
+	CellBomb        byte = '*' // active, lethal bomb
+	CellBombWarning byte = '!' // bomb about to arm
+)
 
 // PlayerSnake is one player's snake state.
 type PlayerSnake struct {
@@ -18,31 +33,35 @@ type PlayerSnake struct {
 	nextDir   snake.Direction
 	Alive     bool
 	Score     int
+	Team      int // -1 outside team games
 }
 
 // Game is the authoritative multiplayer game state.
 // It is driven entirely by the server's tick goroutine; no Bubble Tea dependency.
 type Game struct {
-	matrix    snake.Matrix
+	rules     Rules
+	matrix    snake.Matrix // cell kinds
+	owners    snake.Matrix // player index of each snake cell
 	players   []*PlayerSnake
 	food      []*snake.Point
+	bombs     []*crazy.Bomb
 	over      bool
-	winner    int // -1 = draw, 0-2 = winning player index
+	winner    int // -1 = draw, otherwise the winning player index
+	winTeam   int // -1 = draw or no teams, otherwise the winning team
 	foodCount int // total food eaten globally; drives level calculation
+	clock     time.Duration
+	events    *snake.EventBus
+	rng       *rand.Rand
 }
 
-// NewGame initialises a fresh game for the given player names.
-// len(names) must be between 2 and MaxPlayers.
-func NewGame(names []string) *Game {
-	n := min(len(names), MaxPlayers)
+// NewGame initialises a fresh game for the given player names under rules,
+// which must be valid. len(names) must be between 2 and rules.MaxPlayers.
+func NewGame(names []string, rules Rules) *Game {
+	n := min(len(names), rules.MaxPlayers)
+	rng := snake.NewOptions().Rand()
+	cols, rows := rules.Cols, rules.Rows
 
-	// Fixed spread so snakes start far apart and face inward.
-	starts := [MaxPlayers]snake.Point{
-		{X: snake.DefaultCols / 4, Y: snake.DefaultRows / 2},
-		{X: 3 * snake.DefaultCols / 4, Y: snake.DefaultRows / 2},
-		{X: snake.DefaultCols / 2, Y: snake.DefaultRows / 4},
-	}
-	startDirs := [MaxPlayers]snake.Direction{snake.Right, snake.Left, snake.Down}
+	starts, startDirs := spawns(n, cols, rows)
 
 	players := make([]*PlayerSnake, n)
 	var allPts []snake.Point
@@ -52,6 +71,10 @@ func NewGame(names []string) *Game {
 		if i < len(names) {
 			name = names[i]
 		}
+		team := -1
+		if rules.Teams {
+			team = TeamOf(i)
+		}
 		players[i] = &PlayerSnake{
 			Index:     i,
 			Name:      name,
@@ -59,30 +82,70 @@ func NewGame(names []string) *Game {
 			Direction: startDirs[i],
 			nextDir:   startDirs[i],
 			Alive:     true,
+			Team:      team,
 		}
 		allPts = append(allPts, starts[i])
 	}
 
-	// One food item per player, placed away from all snakes.
-	foods := make([]*snake.Point, n)
+	// Food is placed away from all snakes.
+	foods := make([]*snake.Point, rules.foodFor(n))
 	for i := range foods {
-		f := snake.NewFood(allPts)
+		f := snake.NewFoodIn(rng, rows, cols, allPts)
 		foods[i] = f
 		allPts = append(allPts, *f)
 	}
 
 	g := &Game{
-		matrix:  snake.NewMatrix(snake.DefaultRows, snake.DefaultCols),
+		rules:   rules,
+		matrix:  snake.NewMatrix(rows, cols),
+		owners:  snake.NewMatrix(rows, cols),
 		players: players,
 		food:    foods,
 		over:    false,
 		winner:  -1,
+		winTeam: -1,
+		events:  snake.NewEventBus(),
+		rng:     rng,
 	}
+	g.syncBombs()
 	g.render()
 
 	return g
 }
 
+// spawns spreads n start points evenly around an ellipse centred on the
+// board, each facing inward. Player 0 starts on the left, and with two
+// players the second one faces it from the right.
+func spawns(n, cols, rows int) ([]snake.Point, []snake.Direction) {
+	cx, cy := float64(cols)/2, float64(rows)/2
+	rx, ry := float64(cols)/4, float64(rows)/4
+
+	points := make([]snake.Point, n)
+	dirs := make([]snake.Direction, n)
+	for i := range n {
+		angle := math.Pi + 2*math.Pi*float64(i)/float64(n)
+		dx, dy := math.Cos(angle), math.Sin(angle)
+
+		points[i] = snake.Point{
+			X: int(math.Round(cx + rx*dx)),
+			Y: int(math.Round(cy + ry*dy)),
+		}
+
+		// Face the centre along whichever axis the spawn is further out on.
+		switch {
+		case math.Abs(dx) >= math.Abs(dy) && dx < 0:
+			dirs[i] = snake.Right
+		case math.Abs(dx) >= math.Abs(dy):
+			dirs[i] = snake.Left
+		case dy < 0:
+			dirs[i] = snake.Down
+		default:
+			dirs[i] = snake.Up
+		}
+	}
+	return points, dirs
+}
+
 // ChangeDirection queues a direction change for a player, rejecting 180° reversals.
 // Safe to call from any goroutine that owns the room lock.
 func (g *Game) ChangeDirection(playerIndex int, d snake.Direction) {
@@ -108,9 +171,14 @@ func (g *Game) Tick() []int {
 		return nil
 	}
 
+	g.clock += snake.GetTickInterval(g.Level())
+	g.updateBombs()
+	g.syncBombs()
+
 	type move struct {
-		p    *PlayerSnake
-		next snake.Point
+		p     *PlayerSnake
+		next  snake.Point
+		cause snake.DeathCause
 	}
 
 	// Compute intended next positions.
@@ -121,26 +189,18 @@ func (g *Game) Tick() []int {
 		}
 
 		p.Direction = p.nextDir
-		h := p.Points[0]
-		n := h
-
-		switch p.Direction {
-		case snake.Up:
-			n.Y--
-		case snake.Down:
-			n.Y++
-		case snake.Left:
-			n.X--
-		case snake.Right:
-			n.X++
-		}
-		pending = append(pending, move{p, n})
+		pending = append(pending, move{p: p, next: g.step(p.Points[0], p.Direction)})
 	}
 
-	// 1. Wall collisions.
+	// 1. Wall and bomb collisions.
 	for i := range pending {
-		if !g.matrix.InBounds(pending[i].next) {
+		switch {
+		case !g.matrix.InBounds(pending[i].next):
 			pending[i].p.Alive = false
+			pending[i].cause = snake.CauseWall
+		case g.isActiveBomb(pending[i].next):
+			pending[i].p.Alive = false
+			pending[i].cause = snake.CauseBomb
 		}
 	}
 
@@ -154,17 +214,24 @@ func (g *Game) Tick() []int {
 			if pending[i].next == pending[j].next {
 				pending[i].p.Alive = false
 				pending[j].p.Alive = false
+				pending[i].cause = snake.CauseHeadOn
+				pending[j].cause = snake.CauseHeadOn
 			}
 		}
 	}
 
-	// 3. Head-to-body collisions (mover dies; body owner survives).
-	for _, mv := range pending {
+	// 3. Head-to-body collisions (mover dies; body owner survives). Without
+	// friendly fire, teammates pass through each other.
+	for i := range pending {
+		mv := &pending[i]
 		if !mv.p.Alive {
 			continue
 		}
 	outer:
 		for _, other := range g.players {
+			if other != mv.p && g.teammates(other, mv.p) && !g.rules.FriendlyFire {
+				continue
+			}
 			for si, seg := range other.Points {
 				// The mover's own head is where we're coming from; skip it.
 				if other == mv.p && si == 0 {
@@ -172,6 +239,10 @@ func (g *Game) Tick() []int {
 				}
 				if seg == mv.next {
 					mv.p.Alive = false
+					mv.cause = snake.CauseBody
+					if other == mv.p {
+						mv.cause = snake.CauseSelf
+					}
 					break outer
 				}
 			}
@@ -183,6 +254,7 @@ func (g *Game) Tick() []int {
 	for _, mv := range pending {
 		if !mv.p.Alive {
 			died = append(died, mv.p.Index)
+			g.events.Publish(snake.SnakeDied{Player: mv.p.Index, Name: mv.p.Name, Cause: mv.cause})
 		}
 	}
 
@@ -202,15 +274,32 @@ func (g *Game) Tick() []int {
 
 		mv.p.Points = append([]snake.Point{mv.next}, mv.p.Points...)
 		if ateIdx >= 0 {
+			prevLevel := g.Level()
 			mv.p.Score += 10
 			g.foodCount++
-			g.food[ateIdx] = snake.NewFood(g.allSnakePts())
+			g.food[ateIdx] = snake.NewFoodIn(g.rng, g.rules.Rows, g.rules.Cols, g.allSnakePts())
+
+			g.events.Publish(snake.FoodEaten{Player: mv.p.Index, Name: mv.p.Name, At: mv.next, Points: 10})
+			if g.Level() > prevLevel {
+				g.events.Publish(snake.LevelUp{Level: g.Level()})
+			}
 		} else {
 			mv.p.Points = mv.p.Points[:len(mv.p.Points)-1]
 		}
 	}
 
-	// Check win condition: game ends when ≤1 snake is alive.
+	g.checkWin()
+	g.render()
+	return died
+}
+
+// checkWin ends the game once the rules' win condition is met.
+func (g *Game) checkWin() {
+	if g.rules.Teams {
+		g.checkTeamWin()
+		return
+	}
+
 	alive := 0
 	lastAlive := -1
 	for _, p := range g.players {
@@ -219,13 +308,186 @@ func (g *Game) Tick() []int {
 			lastAlive = p.Index
 		}
 	}
-	if alive <= 1 {
-		g.over = true
-		g.winner = lastAlive // -1 if all died simultaneously this tick
+
+	switch g.rules.Win {
+	case WinFirstTo:
+		reached := false
+		for _, p := range g.players {
+			if p.Score >= g.rules.TargetScore {
+				reached = true
+			}
+		}
+		if reached || alive == 0 {
+			g.over = true
+			g.winner = g.topScorer()
+		}
+
+	case WinTimeLimit:
+		if g.clock >= g.rules.TimeLimit || alive == 0 {
+			g.over = true
+			g.winner = g.topScorer()
+		}
+
+	default:
+		// Last snake standing.
+		if alive <= 1 {
+			g.over = true
+			g.winner = lastAlive // -1 if all died simultaneously this tick
+		}
 	}
+}
 
-	g.render()
-	return died
+// checkTeamWin is checkWin for team games: scores are pooled per team and the
+// last team with a snake left standing wins.
+func (g *Game) checkTeamWin() {
+	var standing []int // teams with a snake alive
+	for _, p := range g.players {
+		if p.Alive && !slices.Contains(standing, p.Team) {
+			standing = append(standing, p.Team)
+		}
+	}
+	scores := g.TeamScores()
+
+	switch g.rules.Win {
+	case WinFirstTo:
+		if slices.Max(scores) >= g.rules.TargetScore || len(standing) == 0 {
+			g.over = true
+			g.winTeam = topTeam(scores)
+		}
+
+	case WinTimeLimit:
+		if g.clock >= g.rules.TimeLimit || len(standing) == 0 {
+			g.over = true
+			g.winTeam = topTeam(scores)
+		}
+
+	default:
+		// Last team standing.
+		if len(standing) <= 1 {
+			g.over = true
+			g.winTeam = -1 // every snake died this tick
+			if len(standing) == 1 {
+				g.winTeam = standing[0]
+			}
+		}
+	}
+
+	if g.over {
+		g.winner = g.teamCaptain(g.winTeam)
+	}
+}
+
+// teamCaptain returns the player standing in for team as the game's winner:
+// its highest scorer, preferring snakes still alive. It returns -1 for team
+// -1.
+func (g *Game) teamCaptain(team int) int {
+	if team < 0 {
+		return -1
+	}
+	var best *PlayerSnake
+	for _, p := range g.players {
+		if p.Team != team {
+			continue
+		}
+		if best == nil || (p.Alive && !best.Alive) || (p.Alive == best.Alive && p.Score > best.Score) {
+			best = p
+		}
+	}
+	if best == nil {
+		return -1
+	}
+	return best.Index
+}
+
+// topTeam returns the team with the highest score, or -1 when the top score
+// is shared.
+func topTeam(scores []int) int {
+	best, team := -1, -1
+	for t, score := range scores {
+		switch {
+		case score > best:
+			best, team = score, t
+		case score == best:
+			team = -1
+		}
+	}
+	return team
+}
+
+// teammates reports whether a and b are on the same team in a team game.
+func (g *Game) teammates(a, b *PlayerSnake) bool {
+	return g.rules.Teams && a.Team == b.Team
+}
+
+// topScorer returns the index of the player with the highest score, or -1
+// when the top score is shared.
+func (g *Game) topScorer() int {
+	best, winner := -1, -1
+	for _, p := range g.players {
+		switch {
+		case p.Score > best:
+			best, winner = p.Score, p.Index
+		case p.Score == best:
+			winner = -1
+		}
+	}
+	return winner
+}
+
+// wrap moves a point that left the board to the opposite edge.
+func (g *Game) wrap(p snake.Point) snake.Point {
+	p.X = (p.X + g.rules.Cols) % g.rules.Cols
+	p.Y = (p.Y + g.rules.Rows) % g.rules.Rows
+	return p
+}
+
+// updateBombs advances every bomb's state machine and publishes any state
+// transition that happened.
+func (g *Game) updateBombs() {
+	for _, b := range g.bombs {
+		occupied := g.occupied(b.Point)
+		if e := b.Update(g.rng, g.rules.Rows, g.rules.Cols, occupied, g.clock); e != nil {
+			g.events.Publish(e)
+		}
+	}
+}
+
+// syncBombs tops the bombs up to the count for the current level when the
+// rules enable them.
+func (g *Game) syncBombs() {
+	if !g.rules.Bombs {
+		return
+	}
+	want := crazy.BombCountForLevel(g.Level())
+	for len(g.bombs) < want {
+		g.bombs = append(g.bombs, crazy.NewBomb(g.rng, g.rules.Rows, g.rules.Cols, g.occupied(snake.Point{X: -1, Y: -1}), g.clock))
+	}
+}
+
+func (g *Game) isActiveBomb(p snake.Point) bool {
+	for _, b := range g.bombs {
+		if b.IsActive() && b.Point == p {
+			return true
+		}
+	}
+	return false
+}
+
+// occupied returns every cell taken by a snake, food or bomb other than the
+// bomb at exclude.
+func (g *Game) occupied(exclude snake.Point) []snake.Point {
+	pts := g.allSnakePts()
+	for _, f := range g.food {
+		if f != nil {
+			pts = append(pts, *f)
+		}
+	}
+	for _, b := range g.bombs {
+		if b.Point != exclude {
+			pts = append(pts, b.Point)
+		}
+	}
+	return pts
 }
 
 // allSnakePts returns every occupied cell across all snakes (for food placement).
@@ -241,13 +503,22 @@ func (g *Game) allSnakePts() []snake.Point {
 func (g *Game) render() {
 	for y := range g.matrix {
 		for x := range g.matrix[y] {
-			g.matrix[y][x] = 0
+			g.matrix[y][x] = CellEmpty
+			g.owners[y][x] = 0
 		}
 	}
 
 	for _, f := range g.food {
 		if f != nil {
-			g.matrix.Set(*f, 'F')
+			g.matrix.Set(*f, CellFood)
+		}
+	}
+
+	for _, b := range g.bombs {
+		if b.IsActive() {
+			g.matrix.Set(b.Point, CellBomb)
+		} else {
+			g.matrix.Set(b.Point, CellBombWarning)
 		}
 	}
 
@@ -257,10 +528,11 @@ func (g *Game) render() {
 		}
 		for i, pt := range p.Points {
 			if i == 0 {
-				g.matrix.Set(pt, HeadCells[p.Index])
+				g.matrix.Set(pt, CellHead)
 			} else {
-				g.matrix.Set(pt, BodyCells[p.Index])
+				g.matrix.Set(pt, CellBody)
 			}
+			g.owners.Set(pt, byte(p.Index))
 		}
 	}
 }
@@ -275,6 +547,7 @@ func (g *Game) Snapshot() map[string]any {
 			"direction": p.Direction,
 			"alive":     p.Alive,
 			"length":    len(p.Points),
+			"team":      p.Team,
 		}
 		if len(p.Points) > 0 {
 			ps["head"] = p.Points[0]
@@ -294,7 +567,11 @@ func (g *Game) Snapshot() map[string]any {
 		"food":      foodPts,
 		"foodCount": g.foodCount,
 		"level":     g.Level(),
+		"bombCount": len(g.bombs),
+		"elapsed":   g.clock.String(),
+		"rules":     g.rules.Name(),
 		"over":      g.over,
 		"winner":    g.winner,
+		"winTeam":   g.winTeam,
 	}
 }
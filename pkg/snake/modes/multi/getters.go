@@ -1,15 +1,36 @@
 package multi
 
-import "github.com/HilthonTT/gosnake/pkg/snake"
+import (
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+)
 
 func (g *Game) Level() int {
-	l := 1 + g.foodCount/5
-	if l > 10 {
-		return 10
+	l := g.rules.StartLevel + g.foodCount/5
+	if l > MaxLevel {
+		return MaxLevel
 	}
 	return l
 }
 
+func (g *Game) Rules() Rules {
+	return g.rules
+}
+
+// Elapsed is the game time played so far.
+func (g *Game) Elapsed() time.Duration {
+	return g.clock
+}
+
+// Remaining is the time left under WinTimeLimit, and zero otherwise.
+func (g *Game) Remaining() time.Duration {
+	if g.rules.Win != WinTimeLimit {
+		return 0
+	}
+	return max(g.rules.TimeLimit-g.clock, 0)
+}
+
 func (g *Game) IsOver() bool {
 	return g.over
 }
@@ -18,10 +39,35 @@ func (g *Game) Winner() int {
 	return g.winner
 }
 
+// WinningTeam is the team that won a team game, or -1 for a draw, a game
+// still in progress or a game without teams.
+func (g *Game) WinningTeam() int {
+	return g.winTeam
+}
+
+// TeamScores returns each team's combined score, indexed by team, or nil
+// outside team games.
+func (g *Game) TeamScores() []int {
+	if !g.rules.Teams {
+		return nil
+	}
+	scores := make([]int, NumTeams)
+	for _, p := range g.players {
+		scores[p.Team] += p.Score
+	}
+	return scores
+}
+
 func (g *Game) Matrix() snake.Matrix {
 	return g.matrix
 }
 
+// Owners is the owner layer of the matrix: for every CellHead or CellBody
+// cell it holds the index of the player the segment belongs to.
+func (g *Game) Owners() snake.Matrix {
+	return g.owners
+}
+
 func (g *Game) Players() []*PlayerSnake {
 	return g.players
 }
@@ -29,3 +75,7 @@ func (g *Game) Players() []*PlayerSnake {
 func (g *Game) FoodCount() int {
 	return g.foodCount
 }
+
+func (g *Game) Events() *snake.EventBus {
+	return g.events
+}
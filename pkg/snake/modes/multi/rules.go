@@ -0,0 +1,232 @@
+package multi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+)
+
+// Board size limits. The lower bound leaves room for every spawn point; the
+// upper bound keeps a two-column-per-cell board inside a normal terminal.
+const (
+	MinCols = 20
+	MinRows = 12
+	MaxCols = 60
+	MaxRows = 40
+
+	// MaxFood bounds Rules.Food.
+	MaxFood = 10
+	// MaxLevel is the highest level the speed curve reaches.
+	MaxLevel = 10
+
+	// NumTeams is the number of teams in a team game.
+	NumTeams = 2
+)
+
+// WinCondition decides when a multiplayer game ends and who wins it.
+type WinCondition int
+
+const (
+	// WinLastAlive ends the game when at most one snake is left.
+	WinLastAlive WinCondition = iota
+	// WinFirstTo ends the game as soon as a player reaches Rules.TargetScore.
+	WinFirstTo
+	// WinTimeLimit ends the game after Rules.TimeLimit of play; the highest
+	// score wins.
+	WinTimeLimit
+)
+
+// Rules configures a multiplayer game. The zero value is not valid; start
+// from DefaultRules.
+type Rules struct {
+	Cols int
+	Rows int
+
+	// Wrap lets snakes leave one edge of the board and enter at the opposite
+	// one instead of dying on the wall.
+	Wrap bool
+
+	// Bombs scatters crazy-mode bombs around the board.
+	Bombs bool
+
+	// Food is the number of food items on the board. Zero means one per
+	// player.
+	Food int
+
+	// StartLevel is the level (and so the speed) the game starts at.
+	StartLevel int
+
+	MaxPlayers int
+
+	// Teams splits the players into NumTeams teams (see TeamOf) that pool
+	// their scores and win together.
+	Teams bool
+
+	// FriendlyFire makes a teammate's body as lethal as an opponent's. Without
+	// it snakes pass through their teammates. Only used with Teams.
+	FriendlyFire bool
+
+	Win         WinCondition
+	TargetScore int           // used by WinFirstTo
+	TimeLimit   time.Duration // used by WinTimeLimit
+}
+
+// DefaultRules returns the classic rules: the default board, solid walls, no
+// bombs, one food per player and last snake standing wins.
+func DefaultRules() Rules {
+	return Rules{
+		Cols:        snake.DefaultCols,
+		Rows:        snake.DefaultRows,
+		StartLevel:  1,
+		MaxPlayers:  MaxPlayers,
+		Win:         WinLastAlive,
+		TargetScore: 200,
+		TimeLimit:   3 * time.Minute,
+	}
+}
+
+// Validate reports the first setting that is out of range.
+func (r Rules) Validate() error {
+	switch {
+	case r.Cols < MinCols || r.Cols > MaxCols:
+		return fmt.Errorf("board width must be between %d and %d", MinCols, MaxCols)
+	case r.Rows < MinRows || r.Rows > MaxRows:
+		return fmt.Errorf("board height must be between %d and %d", MinRows, MaxRows)
+	case r.Food < 0 || r.Food > MaxFood:
+		return fmt.Errorf("food must be between 0 and %d", MaxFood)
+	case r.StartLevel < 1 || r.StartLevel > MaxLevel:
+		return fmt.Errorf("starting level must be between 1 and %d", MaxLevel)
+	case r.MaxPlayers < 2 || r.MaxPlayers > MaxPlayers:
+		return fmt.Errorf("max players must be between 2 and %d", MaxPlayers)
+	}
+
+	switch r.Win {
+	case WinLastAlive:
+	case WinFirstTo:
+		if r.TargetScore <= 0 {
+			return errors.New("target score must be positive")
+		}
+	case WinTimeLimit:
+		if r.TimeLimit <= 0 {
+			return errors.New("time limit must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown win condition %d", r.Win)
+	}
+
+	return nil
+}
+
+// foodFor returns how many food items a game with n players keeps on the board.
+func (r Rules) foodFor(n int) int {
+	if r.Food > 0 {
+		return r.Food
+	}
+	return n
+}
+
+// Name is a short label for the rule set, e.g. "classic" or "crazy/wrap".
+func (r Rules) Name() string {
+	var parts []string
+	if r.Teams {
+		parts = append(parts, "teams")
+	}
+	if r.Bombs {
+		parts = append(parts, "crazy")
+	}
+	if r.Wrap {
+		parts = append(parts, "wrap")
+	}
+	switch r.Win {
+	case WinFirstTo:
+		parts = append(parts, fmt.Sprintf("to-%d", r.TargetScore))
+	case WinTimeLimit:
+		parts = append(parts, "timed")
+	}
+	if len(parts) == 0 {
+		return "classic"
+	}
+	return strings.Join(parts, "/")
+}
+
+// String describes the win condition.
+func (w WinCondition) String() string {
+	switch w {
+	case WinFirstTo:
+		return "first to N points"
+	case WinTimeLimit:
+		return "highest score"
+	default:
+		return "last snake alive"
+	}
+}
+
+// Describe returns one short line per setting, for rule panels.
+func (r Rules) Describe() []string {
+	walls := "solid"
+	if r.Wrap {
+		walls = "wrap"
+	}
+	bombs := "off"
+	if r.Bombs {
+		bombs = "on"
+	}
+	food := "1/player"
+	if r.Food > 0 {
+		food = fmt.Sprint(r.Food)
+	}
+
+	teams := "off"
+	if r.Teams {
+		teams = fmt.Sprintf("%d, FF off", NumTeams)
+		if r.FriendlyFire {
+			teams = fmt.Sprintf("%d, FF on", NumTeams)
+		}
+	}
+
+	win := r.Win.String()
+	switch r.Win {
+	case WinFirstTo:
+		win = fmt.Sprintf("first to %d", r.TargetScore)
+	case WinTimeLimit:
+		win = fmt.Sprintf("best in %s", ShortDuration(r.TimeLimit))
+	case WinLastAlive:
+		if r.Teams {
+			win = "last team alive"
+		}
+	}
+
+	return []string{
+		fmt.Sprintf("Board:  %d×%d", r.Cols, r.Rows),
+		fmt.Sprintf("Walls:  %s", walls),
+		fmt.Sprintf("Bombs:  %s", bombs),
+		fmt.Sprintf("Food:   %s", food),
+		fmt.Sprintf("Speed:  level %d", r.StartLevel),
+		fmt.Sprintf("Seats:  %d", r.MaxPlayers),
+		fmt.Sprintf("Teams:  %s", teams),
+		fmt.Sprintf("Win:    %s", win),
+	}
+}
+
+// TeamOf returns the team of the player at playerIndex in a team game.
+// Players alternate between teams in seat order.
+func TeamOf(playerIndex int) int {
+	return playerIndex % NumTeams
+}
+
+// ShortDuration formats d as "3m", "1m30s" or "45s".
+func ShortDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	m, s := int(d/time.Minute), int(d%time.Minute/time.Second)
+	switch {
+	case m == 0:
+		return fmt.Sprintf("%ds", s)
+	case s == 0:
+		return fmt.Sprintf("%dm", m)
+	default:
+		return fmt.Sprintf("%dm%ds", m, s)
+	}
+}
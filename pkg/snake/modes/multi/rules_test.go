@@ -0,0 +1,52 @@
+package multi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRulesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(r *Rules)
+		wantErr string // empty means valid
+	}{
+		{name: "default", change: func(*Rules) {}},
+		{name: "smallest board", change: func(r *Rules) { r.Cols, r.Rows = MinCols, MinRows }},
+		{name: "largest board", change: func(r *Rules) { r.Cols, r.Rows = MaxCols, MaxRows }},
+		{name: "too narrow", change: func(r *Rules) { r.Cols = MinCols - 1 }, wantErr: "board width"},
+		{name: "too wide", change: func(r *Rules) { r.Cols = MaxCols + 1 }, wantErr: "board width"},
+		{name: "too short", change: func(r *Rules) { r.Rows = MinRows - 1 }, wantErr: "board height"},
+		{name: "too tall", change: func(r *Rules) { r.Rows = MaxRows + 1 }, wantErr: "board height"},
+		{name: "negative food", change: func(r *Rules) { r.Food = -1 }, wantErr: "food"},
+		{name: "too much food", change: func(r *Rules) { r.Food = MaxFood + 1 }, wantErr: "food"},
+		{name: "level zero", change: func(r *Rules) { r.StartLevel = 0 }, wantErr: "starting level"},
+		{name: "level too high", change: func(r *Rules) { r.StartLevel = MaxLevel + 1 }, wantErr: "starting level"},
+		{name: "one player", change: func(r *Rules) { r.MaxPlayers = 1 }, wantErr: "max players"},
+		{name: "too many players", change: func(r *Rules) { r.MaxPlayers = MaxPlayers + 1 }, wantErr: "max players"},
+		{name: "first to", change: func(r *Rules) { r.Win = WinFirstTo }},
+		{name: "first to nothing", change: func(r *Rules) { r.Win, r.TargetScore = WinFirstTo, 0 }, wantErr: "target score"},
+		{name: "timed", change: func(r *Rules) { r.Win = WinTimeLimit }},
+		{name: "no time", change: func(r *Rules) { r.Win, r.TimeLimit = WinTimeLimit, 0 }, wantErr: "time limit"},
+		{name: "target ignored when last alive", change: func(r *Rules) { r.TargetScore, r.TimeLimit = 0, 0 }},
+		{name: "unknown win condition", change: func(r *Rules) { r.Win = WinTimeLimit + 1 }, wantErr: "win condition"},
+		{name: "teams with friendly fire", change: func(r *Rules) { r.Teams, r.FriendlyFire = true, true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := DefaultRules()
+			tt.change(&r)
+
+			err := r.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate() = %v, want nil", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("Validate() = nil, want an error about %s", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("Validate() = %v, want an error about %s", err, tt.wantErr)
+			}
+		})
+	}
+}
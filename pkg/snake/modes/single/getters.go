@@ -45,3 +45,7 @@ func (g *Game) GetTickInterval() time.Duration {
 func (g *Game) GetDefaultTickInterval() time.Duration {
 	return snake.GetTickInterval(1)
 }
+
+func (g *Game) Events() *snake.EventBus {
+	return g.events
+}
@@ -1,6 +1,7 @@
 package single
 
 import (
+	"math/rand"
 	"slices"
 
 	"github.com/HilthonTT/gosnake/internal/data"
@@ -19,9 +20,13 @@ type Game struct {
 	gameOver  bool
 	paused    bool
 	repo      *data.LeaderboardRepository
+	events    *snake.EventBus
+	rng       *rand.Rand
 }
 
-func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
+func NewGame(repo *data.LeaderboardRepository, opts ...snake.Option) (*Game, error) {
+	rng := snake.NewOptions(opts...).Rand()
+
 	matrix := snake.NewMatrix(snake.DefaultRows, snake.DefaultCols)
 
 	scoring, err := snake.NewScoring(1, 10, 100, true, false)
@@ -33,7 +38,7 @@ func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
 		{X: snake.DefaultCols / 2, Y: snake.DefaultRows / 2},
 	}
 
-	food := snake.NewFood(initialSnake)
+	food := snake.NewFood(rng, initialSnake)
 
 	g := &Game{
 		matrix:    matrix,
@@ -45,6 +50,8 @@ func NewGame(repo *data.LeaderboardRepository) (*Game, error) {
 		repo:      repo,
 		paused:    false,
 		gameOver:  false,
+		events:    snake.NewEventBus(),
+		rng:       rng,
 	}
 
 	g.render()
@@ -75,6 +82,7 @@ func (g *Game) TogglePause() {
 	}
 
 	g.paused = !g.paused
+	g.events.Publish(snake.PauseToggled{Paused: g.paused})
 }
 
 // Tick advances the game by one step
@@ -101,13 +109,13 @@ func (g *Game) Tick() {
 
 	// Check if the snake is colliding the wall
 	if !g.matrix.InBounds(next) {
-		g.triggerGameOver()
+		g.triggerGameOver(snake.CauseWall)
 		return
 	}
 
 	// Check if the snake is colliding itself
 	if g.isSelfCollision(next) {
-		g.triggerGameOver()
+		g.triggerGameOver(snake.CauseSelf)
 		return
 	}
 
@@ -118,8 +126,14 @@ func (g *Game) Tick() {
 	g.snakeBody = append([]snake.Point{next}, g.snakeBody...)
 
 	if ateFood {
+		prevLevel := g.scoring.Level()
 		g.scoring.AddPoints(10)
-		g.food = snake.NewFood(g.snakeBody)
+		g.food = snake.NewFood(g.rng, g.snakeBody)
+
+		g.events.Publish(snake.FoodEaten{At: next, Points: 10})
+		if g.scoring.Level() > prevLevel {
+			g.events.Publish(snake.LevelUp{Level: g.scoring.Level()})
+		}
 	} else {
 		// Remove tail
 		g.snakeBody = g.snakeBody[:len(g.snakeBody)-1]
@@ -133,8 +147,9 @@ func (g *Game) SaveScore(name string) error {
 	return err
 }
 
-func (g *Game) triggerGameOver() {
+func (g *Game) triggerGameOver(cause snake.DeathCause) {
 	g.gameOver = true
+	g.events.Publish(snake.SnakeDied{Cause: cause})
 }
 
 // render writes the current game state onto the matrix.
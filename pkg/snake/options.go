@@ -0,0 +1,39 @@
+package snake
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Option configures a new game.
+type Option func(*Options)
+
+// Options holds the settings shared by every game mode.
+type Options struct {
+	// Seed drives every random choice the game makes. Two games with the
+	// same seed that receive the same inputs on the same ticks play out
+	// identically, which is what makes replays verifiable.
+	Seed int64
+}
+
+// WithSeed fixes the game's random seed.
+func WithSeed(seed int64) Option {
+	return func(o *Options) {
+		o.Seed = seed
+	}
+}
+
+// NewOptions applies opts over the defaults. Without WithSeed the seed comes
+// from the clock.
+func NewOptions(opts ...Option) Options {
+	o := Options{Seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Rand returns a random source seeded with o.Seed.
+func (o Options) Rand() *rand.Rand {
+	return rand.New(rand.NewSource(o.Seed))
+}
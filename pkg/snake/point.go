@@ -9,12 +9,19 @@ type Point struct {
 	Y int
 }
 
-func NewFood(snake []Point) *Point {
+// NewFood places food on a random cell of the default board not covered by
+// snake.
+func NewFood(rng *rand.Rand, snake []Point) *Point {
+	return NewFoodIn(rng, DefaultRows, DefaultCols, snake)
+}
+
+// NewFoodIn is like NewFood for a board of the given size.
+func NewFoodIn(rng *rand.Rand, rows, cols int, snake []Point) *Point {
 	p := &Point{}
 
 	for {
-		p.X = rand.Intn(DefaultCols)
-		p.Y = rand.Intn(DefaultRows)
+		p.X = rng.Intn(cols)
+		p.Y = rng.Intn(rows)
 		if !hasExistingPoint(snake, p) {
 			break
 		}
@@ -0,0 +1,139 @@
+package replay
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/crazy"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/single"
+)
+
+// Version is the replay format written by Recorder.
+const Version = 1
+
+// MaxTicks bounds how long a replay may run, so simulating an untrusted
+// replay always finishes. At the fastest speed it is over two days of play.
+const MaxTicks = 1 << 22
+
+// Input is a direction change made just before the given tick.
+type Input struct {
+	Tick int             `json:"tick"`
+	Dir  snake.Direction `json:"dir"`
+}
+
+// Replay is everything needed to play a single-player run again: the mode,
+// the seed behind every random choice, and the player's inputs.
+type Replay struct {
+	Version int            `json:"version"`
+	Mode    snake.GameMode `json:"mode"`
+	Seed    int64          `json:"seed"`
+	Ticks   int            `json:"ticks"` // ticks played until the game ended
+	Inputs  []Input        `json:"inputs"`
+}
+
+// InputHash returns the hex SHA-256 of the input log. It commits a signed
+// claim to the exact inputs without having to ship them.
+func (r Replay) InputHash() string {
+	h := sha256.New()
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, in := range r.Inputs {
+		n := binary.PutUvarint(buf, uint64(in.Tick))
+		h.Write(buf[:n])
+		h.Write([]byte{byte(in.Dir)})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Recorder builds a Replay while a game is played. Call Input for every
+// direction key and Tick after every game tick.
+type Recorder struct {
+	r Replay
+}
+
+func NewRecorder(mode snake.GameMode, seed int64) *Recorder {
+	return &Recorder{r: Replay{Version: Version, Mode: mode, Seed: seed}}
+}
+
+func (rec *Recorder) Input(d snake.Direction) {
+	rec.r.Inputs = append(rec.r.Inputs, Input{Tick: rec.r.Ticks, Dir: d})
+}
+
+func (rec *Recorder) Tick() {
+	rec.r.Ticks++
+}
+
+// Replay returns a copy of everything recorded so far.
+func (rec *Recorder) Replay() Replay {
+	r := rec.r
+	r.Inputs = append([]Input(nil), rec.r.Inputs...)
+	return r
+}
+
+// NewGame starts a game of mode whose random choices all derive from seed.
+func NewGame(mode snake.GameMode, seed int64) (snake.GameController, error) {
+	switch mode {
+	case snake.GameModeNormal:
+		return single.NewGame(nil, snake.WithSeed(seed))
+	case snake.GameModeCrazy:
+		return crazy.NewGame(nil, snake.WithSeed(seed))
+	case snake.GameModeAI:
+		return ai.NewGame(nil, snake.WithSeed(seed))
+	default:
+		return nil, fmt.Errorf("unsupported game mode '%s'", mode)
+	}
+}
+
+// Result is the outcome of simulating a replay.
+type Result struct {
+	Score       int  `json:"score"`
+	Level       int  `json:"level"`
+	SnakeLength int  `json:"snakeLength"`
+	Ticks       int  `json:"ticks"`
+	GameOver    bool `json:"gameOver"`
+}
+
+// Simulate plays r back tick by tick and reports where the game ended up.
+// Simulation stops early if the game ends before r.Ticks.
+func Simulate(r Replay) (Result, error) {
+	if r.Version != Version {
+		return Result{}, fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+	if r.Ticks < 0 || r.Ticks > MaxTicks {
+		return Result{}, fmt.Errorf("replay length %d is out of range", r.Ticks)
+	}
+	for i, in := range r.Inputs {
+		if in.Tick < 0 || in.Tick > r.Ticks || (i > 0 && in.Tick < r.Inputs[i-1].Tick) {
+			return Result{}, fmt.Errorf("input %d has out-of-order tick %d", i, in.Tick)
+		}
+		if in.Dir < snake.Up || in.Dir > snake.Right {
+			return Result{}, fmt.Errorf("input %d has invalid direction %d", i, in.Dir)
+		}
+	}
+
+	g, err := NewGame(r.Mode, r.Seed)
+	if err != nil {
+		return Result{}, err
+	}
+
+	next := 0
+	tick := 0
+	for ; tick < r.Ticks && !g.IsGameOver(); tick++ {
+		for next < len(r.Inputs) && r.Inputs[next].Tick == tick {
+			g.ChangeDirection(r.Inputs[next].Dir)
+			next++
+		}
+		g.Tick()
+	}
+
+	return Result{
+		Score:       g.Score(),
+		Level:       g.Level(),
+		SnakeLength: g.SnakeLength(),
+		Ticks:       tick,
+		GameOver:    g.IsGameOver(),
+	}, nil
+}
@@ -0,0 +1,173 @@
+// Package verify signs single-player runs and checks signed runs by playing
+// their replay again, so a claimed score can be trusted without trusting the
+// machine that submitted it.
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+)
+
+var (
+	// ErrBadSignature means the claim was not signed by the included key.
+	ErrBadSignature = errors.New("signature does not match claim")
+
+	// ErrReplayMismatch means the replay is not the one the claim commits to.
+	ErrReplayMismatch = errors.New("replay does not match claim")
+
+	// ErrScoreMismatch means replaying the run does not reproduce the claim.
+	ErrScoreMismatch = errors.New("replay does not reproduce claimed result")
+)
+
+// Claim is what a player asserts about a finished run.
+type Claim struct {
+	PlayerName  string         `json:"playerName"`
+	Mode        snake.GameMode `json:"mode"`
+	Score       int            `json:"score"`
+	Level       int            `json:"level"`
+	SnakeLength int            `json:"snakeLength"`
+	Seed        int64          `json:"seed"`
+	Ticks       int            `json:"ticks"`
+	InputHash   string         `json:"inputHash"` // see replay.Replay.InputHash
+}
+
+// NewClaim describes the run recorded in r, finished by playerName with res.
+func NewClaim(playerName string, r replay.Replay, res replay.Result) Claim {
+	return Claim{
+		PlayerName:  playerName,
+		Mode:        r.Mode,
+		Score:       res.Score,
+		Level:       res.Level,
+		SnakeLength: res.SnakeLength,
+		Seed:        r.Seed,
+		Ticks:       r.Ticks,
+		InputHash:   r.InputHash(),
+	}
+}
+
+// message is the canonical byte form of c that gets signed. Any client or
+// server verifying a signature must build exactly these bytes.
+func (c Claim) message() []byte {
+	return []byte(strings.Join([]string{
+		"gosnake-run/v1",
+		c.PlayerName,
+		string(c.Mode),
+		strconv.Itoa(c.Score),
+		strconv.Itoa(c.Level),
+		strconv.Itoa(c.SnakeLength),
+		strconv.FormatInt(c.Seed, 10),
+		strconv.Itoa(c.Ticks),
+		c.InputHash,
+	}, "\n"))
+}
+
+// Proof is a claim signed with an install's ed25519 key.
+type Proof struct {
+	Claim
+	PublicKey []byte `json:"publicKey"`
+	Signature []byte `json:"signature"`
+}
+
+// Sign signs c with key.
+func Sign(c Claim, key ed25519.PrivateKey) Proof {
+	return Proof{
+		Claim:     c,
+		PublicKey: key.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(key, c.message()),
+	}
+}
+
+// CheckSignature reports whether p was signed by p.PublicKey.
+func (p Proof) CheckSignature() error {
+	if len(p.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: malformed public key", ErrBadSignature)
+	}
+	if !ed25519.Verify(p.PublicKey, p.Claim.message(), p.Signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// Fingerprint identifies the signing key, in the same format as ssh-keygen -l.
+func (p Proof) Fingerprint() string {
+	return Fingerprint(p.PublicKey)
+}
+
+// Fingerprint returns "SHA256:" followed by the unpadded base64 SHA-256 of key.
+func Fingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// Run is a signed proof together with the replay it commits to. It is the
+// format of replay files written after every game.
+type Run struct {
+	Proof  Proof         `json:"proof"`
+	Replay replay.Replay `json:"replay"`
+}
+
+func ReadRun(r io.Reader) (Run, error) {
+	var run Run
+	if err := json.NewDecoder(r).Decode(&run); err != nil {
+		return Run{}, fmt.Errorf("decode json: %w", err)
+	}
+	return run, nil
+}
+
+func WriteRun(w io.Writer, run Run) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(run); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	return nil
+}
+
+// Verify checks run's signature, checks that its replay is the one the claim
+// commits to, and plays the replay again to confirm the claimed result. The
+// simulated result is returned even when verification fails.
+func Verify(run Run) (replay.Result, error) {
+	if err := run.Proof.CheckSignature(); err != nil {
+		return replay.Result{}, err
+	}
+
+	c, r := run.Proof.Claim, run.Replay
+	switch {
+	case c.Mode != r.Mode:
+		return replay.Result{}, fmt.Errorf("%w: mode %s, replay has %s", ErrReplayMismatch, c.Mode, r.Mode)
+	case c.Seed != r.Seed:
+		return replay.Result{}, fmt.Errorf("%w: seed differs", ErrReplayMismatch)
+	case c.Ticks != r.Ticks:
+		return replay.Result{}, fmt.Errorf("%w: %d ticks, replay has %d", ErrReplayMismatch, c.Ticks, r.Ticks)
+	case c.InputHash != r.InputHash():
+		return replay.Result{}, fmt.Errorf("%w: input log hash differs", ErrReplayMismatch)
+	}
+
+	res, err := replay.Simulate(r)
+	if err != nil {
+		return replay.Result{}, fmt.Errorf("simulate replay: %w", err)
+	}
+
+	switch {
+	case !res.GameOver || res.Ticks != c.Ticks:
+		return res, fmt.Errorf("%w: game ended after %d of %d ticks", ErrScoreMismatch, res.Ticks, c.Ticks)
+	case res.Score != c.Score:
+		return res, fmt.Errorf("%w: score %d, claimed %d", ErrScoreMismatch, res.Score, c.Score)
+	case res.Level != c.Level:
+		return res, fmt.Errorf("%w: level %d, claimed %d", ErrScoreMismatch, res.Level, c.Level)
+	case res.SnakeLength != c.SnakeLength:
+		return res, fmt.Errorf("%w: length %d, claimed %d", ErrScoreMismatch, res.SnakeLength, c.SnakeLength)
+	}
+
+	return res, nil
+}
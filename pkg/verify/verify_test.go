@@ -0,0 +1,152 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/replay"
+)
+
+// chaseTicks is how long playRun steers towards food before letting the
+// snake run into whatever is ahead.
+const chaseTicks = 300
+
+// playRun plays a game of mode to the end, recording it the way the single
+// player view does, and returns the replay and the game's own result.
+func playRun(t *testing.T, mode snake.GameMode, seed int64) (replay.Replay, replay.Result) {
+	t.Helper()
+
+	g, err := replay.NewGame(mode, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := replay.NewRecorder(mode, seed)
+
+	// Games start heading right; a reversal would be ignored, so the snake
+	// sidesteps instead.
+	heading := snake.Right
+	opposite := map[snake.Direction]snake.Direction{
+		snake.Up: snake.Down, snake.Down: snake.Up, snake.Left: snake.Right, snake.Right: snake.Left,
+	}
+
+	for tick := 0; !g.IsGameOver(); tick++ {
+		if tick > replay.MaxTicks {
+			t.Fatal("game never ended")
+		}
+		if food := g.Food(); tick < chaseTicks && food != nil {
+			head := g.Snake()[0]
+			var dir snake.Direction
+			switch {
+			case food.X > head.X:
+				dir = snake.Right
+			case food.X < head.X:
+				dir = snake.Left
+			case food.Y > head.Y:
+				dir = snake.Down
+			default:
+				dir = snake.Up
+			}
+			if dir == opposite[heading] {
+				dir = snake.Down
+				if heading == snake.Up || heading == snake.Down {
+					dir = snake.Right
+				}
+			}
+			heading = dir
+			g.ChangeDirection(dir)
+			rec.Input(dir)
+		}
+		g.Tick()
+		rec.Tick()
+	}
+
+	r := rec.Replay()
+	return r, replay.Result{
+		Score:       g.Score(),
+		Level:       g.Level(),
+		SnakeLength: g.SnakeLength(),
+		Ticks:       r.Ticks,
+		GameOver:    true,
+	}
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	for _, mode := range []snake.GameMode{snake.GameModeNormal, snake.GameModeCrazy} {
+		t.Run(string(mode), func(t *testing.T) {
+			r, want := playRun(t, mode, 42)
+
+			got, err := replay.Simulate(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Fatalf("Simulate() = %+v, the game ended at %+v", got, want)
+			}
+
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			run := Run{Proof: Sign(NewClaim("tester", r, want), key), Replay: r}
+			if got, err := Verify(run); err != nil || got != want {
+				t.Fatalf("Verify() = %+v, %v; want %+v, nil", got, err, want)
+			}
+		})
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	r, res := playRun(t, snake.GameModeNormal, 7)
+	if res.Score == 0 || len(r.Inputs) == 0 {
+		t.Fatalf("test run scored %d with %d inputs; it should score and steer", res.Score, len(r.Inputs))
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		tamper  func(run *Run)
+		wantErr error
+	}{
+		{name: "untouched", tamper: func(*Run) {}},
+		{name: "score raised after signing", tamper: func(run *Run) { run.Proof.Score += 10 }, wantErr: ErrBadSignature},
+		{name: "other key", tamper: func(run *Run) {
+			other, _, _ := ed25519.GenerateKey(rand.Reader)
+			run.Proof.PublicKey = other
+		}, wantErr: ErrBadSignature},
+		{name: "score raised and re-signed", tamper: func(run *Run) {
+			c := run.Proof.Claim
+			c.Score += 10
+			run.Proof = Sign(c, key)
+		}, wantErr: ErrScoreMismatch},
+		{name: "inputs edited", tamper: func(run *Run) {
+			run.Replay.Inputs = run.Replay.Inputs[:len(run.Replay.Inputs)-1]
+		}, wantErr: ErrReplayMismatch},
+		{name: "other seed", tamper: func(run *Run) { run.Replay.Seed++ }, wantErr: ErrReplayMismatch},
+		{name: "other mode", tamper: func(run *Run) { run.Replay.Mode = snake.GameModeCrazy }, wantErr: ErrReplayMismatch},
+		{name: "run cut short and re-signed", tamper: func(run *Run) {
+			run.Replay.Ticks--
+			run.Replay.Inputs = nil
+			run.Proof = Sign(NewClaim("tester", run.Replay, res), key)
+		}, wantErr: ErrScoreMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayed := r
+			replayed.Inputs = append([]replay.Input(nil), r.Inputs...)
+			run := Run{Proof: Sign(NewClaim("tester", replayed, res), key), Replay: replayed}
+			tt.tamper(&run)
+
+			if _, err := Verify(run); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
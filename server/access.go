@@ -0,0 +1,204 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/ssh"
+	"golang.org/x/crypto/bcrypt"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Access decides who may connect to the server and who administers it. Keys
+// are identified by their SHA256 fingerprint, as printed by ssh-keygen -l.
+// A nil *Access lets everyone in and makes nobody an admin.
+type Access struct {
+	allowed    map[string]bool // fingerprints from authorized_keys; nil allows any key
+	admins     map[string]bool
+	bannedKeys map[string]bool
+	bannedNets []*net.IPNet
+}
+
+// NewAccess builds the server's access rules. authorizedKeys is the path of an
+// OpenSSH authorized_keys file listing the only keys allowed in; empty lets
+// any key in. bannedIPs holds addresses or CIDR ranges.
+func NewAccess(authorizedKeys string, admins, bannedKeys, bannedIPs []string) (*Access, error) {
+	a := &Access{
+		admins:     fingerprintSet(admins),
+		bannedKeys: fingerprintSet(bannedKeys),
+	}
+
+	if authorizedKeys != "" {
+		allowed, err := readAuthorizedKeys(authorizedKeys)
+		if err != nil {
+			return nil, err
+		}
+		a.allowed = allowed
+	}
+
+	for _, entry := range bannedIPs {
+		ipNet, err := parseIPNet(entry)
+		if err != nil {
+			return nil, err
+		}
+		a.bannedNets = append(a.bannedNets, ipNet)
+	}
+	return a, nil
+}
+
+func fingerprintSet(fingerprints []string) map[string]bool {
+	set := make(map[string]bool, len(fingerprints))
+	for _, fp := range fingerprints {
+		if fp = strings.TrimSpace(fp); fp != "" {
+			set[fp] = true
+		}
+	}
+	return set
+}
+
+// readAuthorizedKeys returns the fingerprints of every key in an
+// authorized_keys file, skipping comments and blank lines.
+func readAuthorizedKeys(path string) (map[string]bool, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading authorized keys: %w", err)
+	}
+
+	allowed := make(map[string]bool)
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; sc.Scan(); n++ {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		key, _, _, _, err := gossh.ParseAuthorizedKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s line %d: %w", path, n, err)
+		}
+		allowed[gossh.FingerprintSHA256(key)] = true
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading authorized keys: %w", err)
+	}
+	return allowed, nil
+}
+
+// parseIPNet accepts a single address or a CIDR range.
+func parseIPNet(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid banned IP %q", s)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid banned IP range %q: %w", s, err)
+	}
+	return ipNet, nil
+}
+
+// Restricted reports whether only the keys in authorized_keys may connect.
+func (a *Access) Restricted() bool {
+	return a != nil && a.allowed != nil
+}
+
+// IsAdmin reports whether the key with fingerprint fp is an admin's.
+func (a *Access) IsAdmin(fp string) bool {
+	return a != nil && a.admins[fp]
+}
+
+// HasAdmins reports whether any admin keys are configured.
+func (a *Access) HasAdmins() bool {
+	return a != nil && len(a.admins) > 0
+}
+
+// allowAddr reports whether connections from addr are allowed.
+func (a *Access) allowAddr(addr net.Addr) bool {
+	if a == nil || len(a.bannedNets) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return true
+	}
+	for _, ipNet := range a.bannedNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowKey reports whether the key with fingerprint fp may connect. Admin
+// keys always may, even when they are missing from authorized_keys.
+func (a *Access) allowKey(fp string) bool {
+	switch {
+	case a == nil:
+		return true
+	case a.bannedKeys[fp]:
+		return false
+	case a.allowed == nil:
+		return true
+	default:
+		return a.allowed[fp] || a.admins[fp]
+	}
+}
+
+// refusedKey marks a connection that offered a refused key, so it can't fall
+// back to a password.
+type refusedKey struct{}
+
+// publicKeyAuth is the server's public key handler.
+func (a *Access) publicKeyAuth(ctx ssh.Context, key ssh.PublicKey) bool {
+	fp := gossh.FingerprintSHA256(key)
+	if !a.allowAddr(ctx.RemoteAddr()) || !a.allowKey(fp) {
+		log.Printf("refused key %s for %s [%s]", fp, ctx.User(), ctx.RemoteAddr())
+		ctx.SetValue(refusedKey{}, true)
+		return false
+	}
+	return true
+}
+
+// passwordAuth is the server's password handler. Without an allowlist any
+// password is accepted, since sessions without a key can only browse; room
+// passwords are checked separately once a session asks for a room.
+func (a *Access) passwordAuth(ctx ssh.Context, _ string) bool {
+	if !a.allowAddr(ctx.RemoteAddr()) {
+		log.Printf("refused %s [%s]: banned address", ctx.User(), ctx.RemoteAddr())
+		return false
+	}
+	return !a.Restricted() && ctx.Value(refusedKey{}) == nil
+}
+
+// hashPassword hashes a room password for storage. An empty password leaves
+// the room open and has no hash.
+func hashPassword(password string) []byte {
+	if password == "" {
+		return nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		// Lock the room rather than leave it open; no password matches this.
+		log.Printf("failed to hash room password: %v", err)
+		return []byte("!")
+	}
+	return hash
+}
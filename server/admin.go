@@ -0,0 +1,438 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+	"github.com/charmbracelet/ssh"
+)
+
+// adminReplyTimeout bounds how long an admin command waits for a room to act
+// on it.
+const adminReplyTimeout = 2 * time.Second
+
+var errNoRoom = errors.New("no room with that id")
+
+// adminUsage lists the admin commands.
+func adminUsage(reason string) string {
+	lines := []string{
+		"GoSnake Admin",
+		"",
+		"Usage:",
+		"  ssh admin@<host> -p <port> -t admin [rooms]                     list rooms and who is in them",
+		"  ssh admin@<host> -p <port> -t admin kick <room-id> <name|key>   remove a player or bot",
+		"  ssh admin@<host> -p <port> -t admin close <room-id>             close a room",
+		"  ssh admin@<host> -p <port> -t admin notice <message>            post a notice in every room",
+		"  ssh admin@<host> -p <port> -t admin rules <room-id> <k=v>...    change a room's rules",
+		"  ssh admin@<host> -p <port> -t admin limits                      show connection rate limits",
+		"",
+		"Rules:",
+		"  board=<cols>x<rows>  walls=solid|wrap  bombs=on|off  food=<n> (0 = one per player)",
+		"  level=<n>  seats=<n>  teams=off|on|ff  win=last|first:<score>|time:<duration>",
+		"",
+		"Notes:",
+		"  • Only keys listed as admins in the server config can run these commands.",
+		"  • Kicked players can't rejoin the room. Rules change between rounds only.",
+		"  • Players who share a name are kicked by key fingerprint (SHA256:…).",
+		"",
+	}
+	if reason != "" {
+		lines = append(lines, "Error: "+reason, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// adminSession runs an admin command and writes its outcome to s.
+func adminSession(srv *Server, s ssh.Session, args []string) {
+	fingerprint := ""
+	if s.PublicKey() != nil {
+		fingerprint = PublicKey{key: s.PublicKey()}.Fingerprint()
+	}
+	if !srv.access.IsAdmin(fingerprint) {
+		log.Printf("refused admin command from %s [%s]", s.User(), s.RemoteAddr())
+		_, _ = s.Write([]byte(adminUsage("This key is not an admin key.")))
+		_ = s.Exit(1)
+		return
+	}
+
+	log.Printf("admin %s [%s]: %s", fingerprint, s.RemoteAddr(), strings.Join(args, " "))
+	out, err := adminCommand(srv, args)
+	if err != nil {
+		_, _ = s.Write([]byte(adminUsage(err.Error())))
+		_ = s.Exit(1)
+		return
+	}
+	_, _ = s.Write([]byte(out))
+}
+
+func adminCommand(srv *Server, args []string) (string, error) {
+	if len(args) == 0 {
+		return adminRooms(srv), nil
+	}
+
+	findRoom := func(id string) (*Room, error) {
+		room := srv.FindRoom(id)
+		if room == nil {
+			return nil, errNoRoom
+		}
+		return room, nil
+	}
+
+	switch args[0] {
+	case "rooms":
+		return adminRooms(srv), nil
+
+	case "kick":
+		if len(args) < 3 {
+			return "", errors.New("kick needs a room id and a player name or key fingerprint")
+		}
+		// Bot names have a space in them.
+		target := strings.Join(args[2:], " ")
+		room, err := findRoom(args[1])
+		if err != nil {
+			return "", err
+		}
+		if err := room.ask(func(reply chan error) any { return adminKickMsg{target: target, reply: reply} }); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Removed %s from %s.\n", target, args[1]), nil
+
+	case "close":
+		if len(args) != 2 {
+			return "", errors.New("close needs a room id")
+		}
+		room, err := findRoom(args[1])
+		if err != nil {
+			return "", err
+		}
+		room.closeWith("This room was closed by an admin.")
+		return fmt.Sprintf("Closed %s.\n", args[1]), nil
+
+	case "notice":
+		if len(args) < 2 {
+			return "", errors.New("notice needs a message")
+		}
+		text := cleanChat(strings.Join(args[1:], " "))
+		rooms := srv.roomList()
+		for _, room := range rooms {
+			room.post(NoteMsg("Server notice: " + text))
+		}
+		return fmt.Sprintf("Posted in %d room(s).\n", len(rooms)), nil
+
+	case "rules":
+		if len(args) < 3 {
+			return "", errors.New("rules needs a room id and at least one change")
+		}
+		room, err := findRoom(args[1])
+		if err != nil {
+			return "", err
+		}
+		rules, err := changeRules(room.Rules(), args[2:])
+		if err != nil {
+			return "", err
+		}
+		if err := room.ask(func(reply chan error) any { return setRulesMsg{rules: rules, reply: reply} }); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s now plays:\n  %s\n", args[1], strings.Join(rules.Describe(), "\n  ")), nil
+
+	case "limits":
+		return adminLimits(srv), nil
+	}
+	return "", fmt.Errorf("unknown admin command %q", args[0])
+}
+
+// ask sends the message built by msg to listen() and waits for its answer.
+func (r *Room) ask(msg func(reply chan error) any) error {
+	reply := make(chan error, 1)
+	select {
+	case r.sync <- msg(reply):
+	default:
+		return errors.New("the room is busy, try again")
+	}
+
+	select {
+	case err := <-reply:
+		return err
+	case <-time.After(adminReplyTimeout):
+		return errors.New("the room did not respond; it may have closed")
+	}
+}
+
+// adminRooms lists every room with its phase, rules and occupants.
+func adminRooms(srv *Server) string {
+	rooms := srv.roomList()
+	if len(rooms) == 0 {
+		return "No rooms are open.\n"
+	}
+
+	var sb strings.Builder
+	for _, room := range rooms {
+		d := room.detail()
+		fmt.Fprintf(&sb, "%s · %s · %s · %d/%d seats", room.id, d.phase, room.Rules().Name(), len(d.seats), room.Rules().MaxPlayers)
+		if d.locked {
+			sb.WriteString(" · locked")
+		}
+		if room.match != nil {
+			fmt.Fprintf(&sb, " · tournament %s", room.match.tournament)
+		}
+		sb.WriteString("\n")
+		for _, group := range []struct {
+			label string
+			names []string
+		}{{"seats", d.seats}, {"queue", d.queue}, {"watching", d.watching}} {
+			if len(group.names) > 0 {
+				fmt.Fprintf(&sb, "  %-9s %s\n", group.label+":", strings.Join(group.names, ", "))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// roomDetail is what the admin room listing shows of a room.
+type roomDetail struct {
+	phase    string
+	locked   bool
+	seats    []string
+	queue    []string
+	watching []string
+}
+
+func (r *Room) detail() roomDetail {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d := roomDetail{locked: r.password != nil}
+	switch r.phase {
+	case PhaseWaiting:
+		d.phase = "waiting"
+	case PhaseCountdown:
+		d.phase = "countdown"
+	case PhasePlaying:
+		d.phase = "playing"
+	}
+
+	for _, st := range r.seats {
+		var tags []string
+		switch {
+		case st.bot:
+			tags = append(tags, st.difficulty.String()+" bot")
+		case st.key == r.owner:
+			tags = append(tags, "owner")
+		}
+		if _, away := r.away[st.key]; away {
+			tags = append(tags, "away")
+		} else if st.ready && !st.bot {
+			tags = append(tags, "ready")
+		}
+		name := st.name
+		if len(tags) > 0 {
+			name += " (" + strings.Join(tags, ", ") + ")"
+		}
+		d.seats = append(d.seats, name)
+	}
+	for _, q := range r.queue {
+		d.queue = append(d.queue, q.name)
+	}
+	for _, p := range r.players {
+		if p.playerIndex < 0 && !p.queued {
+			d.watching = append(d.watching, p.session.User())
+		}
+	}
+	slices.Sort(d.watching)
+	return d
+}
+
+// adminKick removes a bot called target, or the player whose name or key
+// fingerprint is target, from the room for good. Names are chosen by the
+// client, so a name shared by several keys is refused and the matching
+// fingerprints are listed instead. Must only be called from listen().
+func (r *Room) adminKick(target string) error {
+	r.mu.Lock()
+	for _, st := range r.seats {
+		if st.bot && st.name == target {
+			if r.phase == PhasePlaying {
+				r.mu.Unlock()
+				return errors.New("bots can only be removed between rounds")
+			}
+			r.removeBotLocked(st.key)
+			if r.phase == PhaseCountdown && len(r.seats) < 2 {
+				r.phase = PhaseWaiting
+			}
+			r.mu.Unlock()
+
+			r.sendNote(fmt.Sprintf("%s was removed by an admin", target))
+			r.broadcastRoom()
+			return nil
+		}
+	}
+
+	var found []*Player
+	for _, p := range r.players {
+		if p.key.Fingerprint() == target || p.session.User() == target {
+			found = append(found, p)
+		}
+	}
+	switch {
+	case len(found) == 0:
+		r.mu.Unlock()
+		return fmt.Errorf("nobody called %s is in the room", target)
+	case len(found) > 1:
+		r.mu.Unlock()
+		matches := make([]string, len(found))
+		for i, p := range found {
+			matches[i] = fmt.Sprintf("%s %s", p.session.User(), p.key.Fingerprint())
+		}
+		slices.Sort(matches)
+		return fmt.Errorf("%d players are called %s; kick one by key fingerprint:\n  %s",
+			len(found), target, strings.Join(matches, "\n  "))
+	}
+	p := found[0]
+	key := p.key.String()
+	r.kicked[key] = true
+	delete(r.away, key)
+	r.mu.Unlock()
+
+	_, _ = p.WriteString("\nYou were removed from the room by an admin.\n")
+	p.closeOnce()
+	r.sendNote(fmt.Sprintf("%s was removed by an admin", p.session.User()))
+	r.leave(key)
+	return nil
+}
+
+// setRules changes the rules for the rounds to come. Must only be called from
+// listen().
+func (r *Room) setRules(rules multi.Rules) error {
+	r.mu.RLock()
+	phase, seated := r.phase, len(r.seats)
+	r.mu.RUnlock()
+
+	switch {
+	case r.match != nil:
+		return errors.New("tournament matches are played under fixed rules")
+	case phase != PhaseWaiting:
+		return errors.New("the room is mid-round; try again once the round is over")
+	case seated > rules.MaxPlayers:
+		return fmt.Errorf("%d players are seated, more than %d seats", seated, rules.MaxPlayers)
+	}
+
+	r.rules.Store(&rules)
+	r.sendNote("An admin changed the rules to " + rules.Name())
+	r.broadcastRoom()
+	return nil
+}
+
+// changeRules applies setting=value changes to rules, as typed after
+// `admin rules <room-id>`.
+func changeRules(rules multi.Rules, changes []string) (multi.Rules, error) {
+	onOff := func(v string) (bool, error) {
+		switch v {
+		case "on":
+			return true, nil
+		case "off":
+			return false, nil
+		}
+		return false, fmt.Errorf("%q is not on or off", v)
+	}
+
+	for _, change := range changes {
+		k, v, ok := strings.Cut(change, "=")
+		if !ok {
+			return rules, fmt.Errorf("%q is not a setting=value change", change)
+		}
+
+		var err error
+		switch k {
+		case "board":
+			cols, rows, found := strings.Cut(v, "x")
+			if !found {
+				return rules, fmt.Errorf("board %q is not <cols>x<rows>", v)
+			}
+			if rules.Cols, err = strconv.Atoi(cols); err == nil {
+				rules.Rows, err = strconv.Atoi(rows)
+			}
+		case "walls":
+			switch v {
+			case "solid":
+				rules.Wrap = false
+			case "wrap":
+				rules.Wrap = true
+			default:
+				err = fmt.Errorf("walls %q is not solid or wrap", v)
+			}
+		case "bombs":
+			rules.Bombs, err = onOff(v)
+		case "food":
+			rules.Food, err = strconv.Atoi(v)
+		case "level":
+			rules.StartLevel, err = strconv.Atoi(v)
+		case "seats":
+			rules.MaxPlayers, err = strconv.Atoi(v)
+		case "teams":
+			switch v {
+			case "off":
+				rules.Teams, rules.FriendlyFire = false, false
+			case "on":
+				rules.Teams, rules.FriendlyFire = true, false
+			case "ff":
+				rules.Teams, rules.FriendlyFire = true, true
+			default:
+				err = fmt.Errorf("teams %q is not off, on or ff", v)
+			}
+		case "win":
+			kind, arg, _ := strings.Cut(v, ":")
+			switch kind {
+			case "last":
+				rules.Win = multi.WinLastAlive
+			case "first":
+				rules.Win = multi.WinFirstTo
+				rules.TargetScore, err = strconv.Atoi(arg)
+			case "time":
+				rules.Win = multi.WinTimeLimit
+				rules.TimeLimit, err = time.ParseDuration(arg)
+			default:
+				err = fmt.Errorf("win %q is not last, first:<score> or time:<duration>", v)
+			}
+		default:
+			err = fmt.Errorf("unknown setting %q", k)
+		}
+		if err != nil {
+			return rules, fmt.Errorf("%s: %w", change, err)
+		}
+	}
+
+	if err := rules.Validate(); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
+
+// adminLimits shows the connection limits and every IP currently tracked by
+// the per-IP rate limiter.
+func adminLimits(srv *Server) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Per IP: %g connections/s, burst %d. Server: %g connections/s, burst %d, %d sessions at most.\n\n",
+		float64(ipRateLimit), ipBurst, float64(globalRateLimit), globalBurst, globalMaxSessions)
+
+	ips := srv.ipLimiter.status()
+	if len(ips) == 0 {
+		sb.WriteString("No addresses are being tracked.\n")
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "%-40s %7s  %s\n", "ADDRESS", "TOKENS", "LAST SEEN")
+	for _, ip := range ips {
+		state := ""
+		if ip.tokens < 1 {
+			state = "  throttled"
+		}
+		fmt.Fprintf(&sb, "%-40s %7.1f  %s ago%s\n", ip.ip, ip.tokens, time.Since(ip.lastSeen).Round(time.Second), state)
+	}
+	return sb.String()
+}
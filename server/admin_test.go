@@ -0,0 +1,115 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+	"github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// fakeSession is just enough of an ssh.Session for a room to write to and
+// close.
+type fakeSession struct {
+	ssh.Session
+	user   string
+	closed bool
+}
+
+func (s *fakeSession) User() string                { return s.user }
+func (s *fakeSession) Write(b []byte) (int, error) { return len(b), nil }
+func (s *fakeSession) Close() error                { s.closed = true; return nil }
+
+// watch adds a spectator called user to room with a fresh key.
+func watch(t *testing.T, room *Room, user string) (*Player, *fakeSession) {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeSession{user: user}
+	p := &Player{room: room, session: s, playerIndex: -1, key: PublicKey{key: key}}
+	room.mu.Lock()
+	room.players[p.key.String()] = p
+	room.mu.Unlock()
+	return p, s
+}
+
+func TestAdminKick(t *testing.T) {
+	tests := []struct {
+		name    string
+		users   []string
+		target  func(players []*Player) string
+		kicked  int // index into players, or -1 for nobody
+		wantErr string
+	}{
+		{name: "by name", users: []string{"ada", "grace"},
+			target: func([]*Player) string { return "ada" }, kicked: 0},
+		{name: "shared name", users: []string{"ada", "ada"},
+			target: func([]*Player) string { return "ada" }, kicked: -1, wantErr: "2 players are called ada"},
+		{name: "shared name by fingerprint", users: []string{"ada", "ada"},
+			target: func(p []*Player) string { return p[1].key.Fingerprint() }, kicked: 1},
+		{name: "nobody", users: []string{"ada"},
+			target: func([]*Player) string { return "grace" }, kicked: -1, wantErr: "nobody called grace"},
+	}
+
+	srv, err := NewServer(filepath.Join(t.TempDir(), "host_key"), "127.0.0.1", 0, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.tournaments.stop()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room, err := srv.CreateRoom(strings.ReplaceAll(tt.name, " ", "-"), "", multi.DefaultRules())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer room.Close()
+
+			var players []*Player
+			var sessions []*fakeSession
+			for _, user := range tt.users {
+				p, s := watch(t, room, user)
+				players = append(players, p)
+				sessions = append(sessions, s)
+			}
+
+			target := tt.target(players)
+			err = room.ask(func(reply chan error) any { return adminKickMsg{target: target, reply: reply} })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("kick %q: err = %v, want %q", target, err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("kick %q: %v", target, err)
+			}
+			if strings.Contains(tt.wantErr, "players are called") {
+				for _, p := range players {
+					if !strings.Contains(err.Error(), p.key.Fingerprint()) {
+						t.Errorf("refusal does not list %s", p.key.Fingerprint())
+					}
+				}
+			}
+
+			room.mu.RLock()
+			defer room.mu.RUnlock()
+			for i, p := range players {
+				kicked := room.kicked[p.key.String()]
+				if want := i == tt.kicked; kicked != want || sessions[i].closed != want {
+					t.Errorf("player %d kicked = %t, closed = %t; want %t", i, kicked, sessions[i].closed, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
+)
+
+// botKeyPrefix marks the seat keys of bots. It can never be the string form of
+// an SSH public key, so bot and player keys don't collide.
+const botKeyPrefix = "bot:"
+
+// addBot seats a bot of difficulty d between rounds if key is the owner's and
+// a seat is free.
+func (r *Room) addBot(key string, d ai.Difficulty) {
+	r.mu.Lock()
+	p, ok := r.players[key]
+	if !ok || key != r.owner || r.phase != PhaseWaiting {
+		r.mu.Unlock()
+		return
+	}
+	if r.match != nil {
+		r.mu.Unlock()
+		p.Send(NoteMsg("Bots can't play tournament matches"))
+		return
+	}
+	if len(r.seats) >= r.Rules().MaxPlayers {
+		r.mu.Unlock()
+		p.Send(NoteMsg("No free seat for a bot"))
+		return
+	}
+
+	st := r.newBotSeatLocked(d)
+	r.seats = append(r.seats, st)
+	r.mu.Unlock()
+
+	r.sendNote(fmt.Sprintf("%s (%s) takes a seat", st.name, d))
+	r.broadcastRoom()
+	r.maybeStart()
+}
+
+// newBotSeatLocked returns a ready seat for a bot named after the lowest free
+// bot number. Caller must hold r.mu.
+func (r *Room) newBotSeatLocked(d ai.Difficulty) *seat {
+	n := 1
+	for slices.ContainsFunc(r.seats, func(st *seat) bool { return st.key == botKey(n) }) {
+		n++
+	}
+	return &seat{
+		key:        botKey(n),
+		name:       fmt.Sprintf("Bot %d", n),
+		ready:      true,
+		bot:        true,
+		difficulty: d,
+	}
+}
+
+func botKey(n int) string {
+	return fmt.Sprintf("%s%d", botKeyPrefix, n)
+}
+
+// removeBotLocked frees the bot seat held under key and returns it, or nil if
+// key is not a bot's. Only valid between rounds. Caller must hold r.mu.Lock.
+func (r *Room) removeBotLocked(key string) *seat {
+	i := r.seatIndexLocked(key)
+	if i < 0 || !r.seats[i].bot {
+		return nil
+	}
+	st := r.seats[i]
+	r.seats = slices.Delete(r.seats, i, i+1)
+	r.reindexLocked()
+	return st
+}
+
+// yieldBotLocked frees the last bot seat so a person can have it. It reports
+// false if no bot is seated. Caller must hold r.mu.Lock.
+func (r *Room) yieldBotLocked() bool {
+	for i := len(r.seats) - 1; i >= 0; i-- {
+		if r.seats[i].bot {
+			return r.removeBotLocked(r.seats[i].key) != nil
+		}
+	}
+	return false
+}
+
+// humansLocked counts the seats held by people. Caller must hold r.mu.
+func (r *Room) humansLocked() int {
+	n := 0
+	for _, st := range r.seats {
+		if !st.bot {
+			n++
+		}
+	}
+	return n
+}
+
+// startBots gives every bot seat a fresh brain for the round about to start.
+// Must only be called from listen().
+func (r *Room) startBots() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	r.bots = make(map[int]*ai.Bot)
+	for i, st := range r.seats {
+		if st.bot {
+			rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(i)))
+			r.bots[i] = ai.NewBot(st.difficulty, rng)
+		}
+	}
+}
+
+// steerBots lets every bot choose its direction for the next tick. Must only
+// be called from listen().
+func (r *Room) steerBots() {
+	for idx, bot := range r.bots {
+		r.game.Steer(idx, bot)
+	}
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"math/bits"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+)
+
+// byeName fills a bracket slot that no player will take. A player facing a
+// bye advances without playing.
+const byeName = "(bye)"
+
+// newBracket builds the matches of a format bracket for players, given in
+// seed order. The field is padded with byes to a power of two, the byes going
+// to the top seeds. Double elimination brackets have a single grand final
+// between the winners' and losers' bracket champions.
+func newBracket(format string, players []string) []data.TournamentMatch {
+	size := 2
+	if len(players) > 2 {
+		size = 1 << bits.Len(uint(len(players)-1))
+	}
+	rounds := bits.Len(uint(size)) - 1
+	double := format == data.DoubleElimination
+
+	var matches []data.TournamentMatch
+	add := func(bracket string, round int) int {
+		id := len(matches) + 1
+		matches = append(matches, data.TournamentMatch{ID: id, Bracket: bracket, Round: round})
+		return id
+	}
+
+	// Lay out every match first, then link them up.
+	wb := make([][]int, rounds+1)
+	for r := 1; r <= rounds; r++ {
+		for range size >> r {
+			wb[r] = append(wb[r], add(data.BracketWinners, r))
+		}
+	}
+	lbRounds := 0
+	if double {
+		lbRounds = 2 * (rounds - 1)
+	}
+	lb := make([][]int, lbRounds+1)
+	for r := 1; r <= lbRounds; r++ {
+		for range size >> ((r+1)/2 + 1) {
+			lb[r] = append(lb[r], add(data.BracketLosers, r))
+		}
+	}
+	final := 0
+	if double {
+		final = add(data.BracketFinal, 1)
+	}
+
+	link := func(from, to, slot int, loser bool) {
+		m := &matches[from-1]
+		if loser {
+			m.LoserNext, m.LoserSlot = to, slot
+		} else {
+			m.Next, m.NextSlot = to, slot
+		}
+	}
+
+	for r := 1; r <= rounds; r++ {
+		for j, id := range wb[r] {
+			if r < rounds {
+				link(id, wb[r+1][j/2], j%2, false)
+			} else if double {
+				link(id, final, 0, false)
+			}
+			if !double {
+				continue
+			}
+
+			// Losers drop into the losers' bracket: first-round losers
+			// pair up, later ones meet the survivors there. Alternate
+			// rounds drop in reverse order to put off rematches.
+			switch {
+			case rounds == 1:
+				link(id, final, 1, true)
+			case r == 1:
+				link(id, lb[1][j/2], j%2, true)
+			default:
+				drop := lb[2*(r-1)]
+				k := j
+				if r%2 == 0 {
+					k = len(drop) - 1 - j
+				}
+				link(id, drop[k], 1, true)
+			}
+		}
+	}
+	for r := 1; r <= lbRounds; r++ {
+		for j, id := range lb[r] {
+			switch {
+			case r == lbRounds:
+				link(id, final, 1, false)
+			case r%2 == 1:
+				link(id, lb[r+1][j], 0, false)
+			default:
+				link(id, lb[r+1][j/2], j%2, false)
+			}
+		}
+	}
+
+	for i, seed := range seedOrder(size) {
+		name := byeName
+		if seed <= len(players) {
+			name = players[seed-1]
+		}
+		matches[wb[1][i/2]-1].Players[i%2] = name
+	}
+	settleByes(matches)
+	return matches
+}
+
+// seedOrder returns the seeds 1..size in bracket order, so that the top
+// seeds can only meet in the latest rounds.
+func seedOrder(size int) []int {
+	order := []int{1, 2}
+	for len(order) < size {
+		n := 2 * len(order)
+		next := make([]int, 0, n)
+		for _, s := range order {
+			next = append(next, s, n+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+// decideMatch records the winner of a match and moves both players on. It
+// returns the tournament's champion once the deciding match is won, or "".
+func decideMatch(matches []data.TournamentMatch, id int, winner string) string {
+	m := &matches[id-1]
+	m.Winner = winner
+	loser := m.Players[0]
+	if loser == winner {
+		loser = m.Players[1]
+	}
+
+	if m.Next > 0 {
+		matches[m.Next-1].Players[m.NextSlot] = winner
+	}
+	if m.LoserNext > 0 {
+		matches[m.LoserNext-1].Players[m.LoserSlot] = loser
+	}
+	if m.Next == 0 && winner != byeName {
+		return winner
+	}
+	return settleByes(matches)
+}
+
+// settleByes advances every player drawn against a bye, including byes met
+// further along the bracket, and returns the champion if that decides the
+// tournament.
+func settleByes(matches []data.TournamentMatch) string {
+	for i := range matches {
+		m := &matches[i]
+		if m.Winner != "" || m.Players[0] == "" || m.Players[1] == "" {
+			continue
+		}
+		switch byeName {
+		case m.Players[0]:
+			return decideMatch(matches, m.ID, m.Players[1])
+		case m.Players[1]:
+			return decideMatch(matches, m.ID, m.Players[0])
+		}
+	}
+	return ""
+}
+
+// playable reports whether m is waiting to be played: both players are
+// known, neither is a bye, and it has no winner yet.
+func playable(m data.TournamentMatch) bool {
+	return m.Winner == "" && m.Players[0] != "" && m.Players[1] != "" &&
+		m.Players[0] != byeName && m.Players[1] != byeName
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+)
+
+// seededPlayers returns the players p1..pn in seed order.
+func seededPlayers(n int) []string {
+	players := make([]string, n)
+	for i := range players {
+		players[i] = fmt.Sprintf("p%d", i+1)
+	}
+	return players
+}
+
+func seedOf(name string) int {
+	n, _ := strconv.Atoi(name[1:])
+	return n
+}
+
+// favourite wins every match for the better seed.
+func favourite(m data.TournamentMatch) string {
+	if seedOf(m.Players[0]) < seedOf(m.Players[1]) {
+		return m.Players[0]
+	}
+	return m.Players[1]
+}
+
+// underdog wins every match for the worse seed.
+func underdog(m data.TournamentMatch) string {
+	if seedOf(m.Players[0]) > seedOf(m.Players[1]) {
+		return m.Players[0]
+	}
+	return m.Players[1]
+}
+
+// upsetFinal lets the favourite win everything but the grand final, which the
+// losers' bracket champion takes.
+func upsetFinal(m data.TournamentMatch) string {
+	if m.Bracket == data.BracketFinal {
+		return m.Players[1]
+	}
+	return favourite(m)
+}
+
+// playOut plays every match as it becomes playable, picking winners with win,
+// and returns the champion and the number of matches played.
+func playOut(t *testing.T, matches []data.TournamentMatch, win func(data.TournamentMatch) string) (string, int) {
+	t.Helper()
+
+	for played := 0; played <= len(matches); played++ {
+		i := slices.IndexFunc(matches, playable)
+		if i < 0 {
+			t.Fatalf("no playable match left after %d played, and no champion", played)
+		}
+		if champion := decideMatch(matches, matches[i].ID, win(matches[i])); champion != "" {
+			return champion, played + 1
+		}
+	}
+	t.Fatal("bracket never produced a champion")
+	return "", 0
+}
+
+func TestSeedOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{2, []int{1, 2}},
+		{4, []int{1, 4, 2, 3}},
+		{8, []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+
+	for _, tt := range tests {
+		if got := seedOrder(tt.size); !slices.Equal(got, tt.want) {
+			t.Errorf("seedOrder(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestNewBracket(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		players int
+
+		wantMatches int
+		wantLosers  int
+		wantFinal   int
+		wantByeWins map[int]string // matches decided by a bye at the draw
+	}{
+		{name: "single, two players", format: data.SingleElimination, players: 2, wantMatches: 1},
+		{name: "single, one bye", format: data.SingleElimination, players: 3, wantMatches: 3,
+			wantByeWins: map[int]string{1: "p1"}},
+		{name: "single, three byes", format: data.SingleElimination, players: 5, wantMatches: 7,
+			wantByeWins: map[int]string{1: "p1", 3: "p2", 4: "p3"}},
+		{name: "single, full", format: data.SingleElimination, players: 8, wantMatches: 7},
+		{name: "double, two players", format: data.DoubleElimination, players: 2, wantMatches: 2, wantFinal: 1},
+		{name: "double, one bye", format: data.DoubleElimination, players: 3, wantMatches: 6, wantLosers: 2, wantFinal: 1,
+			wantByeWins: map[int]string{1: "p1"}},
+		{name: "double, three byes", format: data.DoubleElimination, players: 5, wantMatches: 14, wantLosers: 6, wantFinal: 1,
+			wantByeWins: map[int]string{1: "p1", 3: "p2", 4: "p3", 9: byeName}},
+		{name: "double, full", format: data.DoubleElimination, players: 8, wantMatches: 14, wantLosers: 6, wantFinal: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := newBracket(tt.format, seededPlayers(tt.players))
+
+			if len(matches) != tt.wantMatches {
+				t.Fatalf("got %d matches, want %d", len(matches), tt.wantMatches)
+			}
+			count := map[string]int{}
+			for i, m := range matches {
+				if m.ID != i+1 {
+					t.Errorf("match %d has id %d", i+1, m.ID)
+				}
+				count[m.Bracket]++
+				if want := tt.wantByeWins[m.ID]; m.Winner != want {
+					t.Errorf("match %d winner = %q, want %q", m.ID, m.Winner, want)
+				}
+			}
+			if count[data.BracketLosers] != tt.wantLosers || count[data.BracketFinal] != tt.wantFinal {
+				t.Errorf("got %d losers' and %d final matches, want %d and %d",
+					count[data.BracketLosers], count[data.BracketFinal], tt.wantLosers, tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestNewBracketLosersRoutes(t *testing.T) {
+	matches := newBracket(data.DoubleElimination, seededPlayers(8))
+
+	// Winners' bracket match -> losers' bracket match and slot. Round two
+	// losers drop in reverse order to put off rematches.
+	want := map[int][2]int{
+		1: {8, 0}, 2: {8, 1},
+		3: {9, 0}, 4: {9, 1},
+		5: {11, 1}, 6: {10, 1},
+		7: {13, 1},
+	}
+	for id, route := range want {
+		m := matches[id-1]
+		if got := [2]int{m.LoserNext, m.LoserSlot}; got != route {
+			t.Errorf("loser of match %d goes to %v, want %v", id, got, route)
+		}
+	}
+
+	final := matches[len(matches)-1]
+	if final.Bracket != data.BracketFinal || final.Next != 0 {
+		t.Fatalf("last match is %+v, want the grand final", final)
+	}
+	if wb, lb := matches[6], matches[12]; wb.Next != final.ID || wb.NextSlot != 0 || lb.Next != final.ID || lb.NextSlot != 1 {
+		t.Errorf("grand final is fed by %d/%d and %d/%d, want 7/0 and 13/1", wb.Next, wb.NextSlot, lb.Next, lb.NextSlot)
+	}
+}
+
+func TestDecideMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		players int
+		win     func(data.TournamentMatch) string
+
+		wantChampion string
+		wantPlayed   int
+	}{
+		{name: "single, favourites", format: data.SingleElimination, players: 8, win: favourite,
+			wantChampion: "p1", wantPlayed: 7},
+		{name: "single, underdogs", format: data.SingleElimination, players: 8, win: underdog,
+			wantChampion: "p8", wantPlayed: 7},
+		{name: "single, byes", format: data.SingleElimination, players: 5, win: favourite,
+			wantChampion: "p1", wantPlayed: 4},
+		{name: "single, byes and underdogs", format: data.SingleElimination, players: 3, win: underdog,
+			wantChampion: "p3", wantPlayed: 2},
+		{name: "double, two players", format: data.DoubleElimination, players: 2, win: favourite,
+			wantChampion: "p1", wantPlayed: 2},
+		{name: "double, favourites", format: data.DoubleElimination, players: 8, win: favourite,
+			wantChampion: "p1", wantPlayed: 14},
+		{name: "double, underdogs", format: data.DoubleElimination, players: 8, win: underdog,
+			wantChampion: "p8", wantPlayed: 14},
+		{name: "double, losers' bracket champion", format: data.DoubleElimination, players: 8, win: upsetFinal,
+			wantChampion: "p2", wantPlayed: 14},
+		{name: "double, byes", format: data.DoubleElimination, players: 5, win: favourite,
+			wantChampion: "p1", wantPlayed: 8},
+		{name: "double, byes and underdogs", format: data.DoubleElimination, players: 3, win: underdog,
+			wantChampion: "p3", wantPlayed: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := newBracket(tt.format, seededPlayers(tt.players))
+
+			champion, played := playOut(t, matches, tt.win)
+			if champion != tt.wantChampion {
+				t.Errorf("champion = %q, want %q", champion, tt.wantChampion)
+			}
+			if played != tt.wantPlayed {
+				t.Errorf("played %d matches, want %d", played, tt.wantPlayed)
+			}
+			for _, m := range matches {
+				if playable(m) {
+					t.Errorf("match %d is still playable after the final: %+v", m.ID, m)
+				}
+			}
+		})
+	}
+}
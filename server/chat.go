@@ -0,0 +1,81 @@
+package server
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxChatLen is the longest chat message, in runes, a player may send.
+	maxChatLen = 120
+	// maxChatNameLen caps sender names shown in the chat panel.
+	maxChatNameLen = 16
+	// chatScrollback is how many messages each session keeps.
+	chatScrollback = 20
+	// chatLines is how many lines of scrollback the chat panel shows.
+	chatLines = 6
+
+	// chatRate is the sustained number of messages a player may send.
+	chatRate = rate.Limit(0.5) // one message every 2 seconds
+	// chatBurst lets a player send a few messages in quick succession.
+	chatBurst = 4
+)
+
+// chatBlocklist holds word stems masked out of chat messages. It is not meant
+// to be exhaustive; it keeps the most common offenders off shared screens.
+var chatBlocklist = []string{
+	"fuck",
+	"shit",
+	"cunt",
+	"bitch",
+	"asshole",
+	"bastard",
+	"wanker",
+	"motherf",
+}
+
+// newChatLimiter returns the rate limiter for one player's chat messages.
+func newChatLimiter() *rate.Limiter {
+	return rate.NewLimiter(chatRate, chatBurst)
+}
+
+// cleanChat makes untrusted text safe to show in other players' terminals:
+// control characters (and so escape sequences) are dropped, runs of
+// whitespace collapse to one space, blocklisted words are masked and the
+// result is cut to maxChatLen runes.
+func cleanChat(text string) string {
+	text = truncate(printable(text), maxChatLen)
+
+	words := strings.Fields(text)
+	for i, w := range words {
+		lower := strings.ToLower(w)
+		for _, bad := range chatBlocklist {
+			if strings.Contains(lower, bad) {
+				words[i] = strings.Repeat("*", len([]rune(w)))
+				break
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// cleanName makes an SSH user name safe to show as a chat sender.
+func cleanName(name string) string {
+	name = truncate(strings.Join(strings.Fields(printable(name)), " "), maxChatNameLen)
+	if name == "" {
+		return "anonymous"
+	}
+	return name
+}
+
+// printable replaces control and other non-printing runes with spaces.
+func printable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsPrint(r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
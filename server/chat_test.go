@@ -0,0 +1,39 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCleanChat(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "gg well played", want: "gg well played"},
+		{name: "whitespace collapses", in: "  so   close\t\tnext time  ", want: "so close next time"},
+		{name: "escape sequences", in: "\x1b[2Jhi\x1b[31mthere", want: "[2Jhi [31mthere"},
+		{name: "newlines", in: "one\ntwo\r\nthree", want: "one two three"},
+		{name: "blocklisted word", in: "oh shit", want: "oh ****"},
+		{name: "blocklisted stem, any case", in: "FUCKING lag", want: "******* lag"},
+		{name: "multibyte mask", in: "shït shité", want: "shït *****"},
+		{name: "only control characters", in: "\x00\x07\x1b", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanChat(tt.in); got != tt.want {
+				t.Errorf("cleanChat(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanChatLength(t *testing.T) {
+	got := cleanChat(strings.Repeat("é", 2*maxChatLen))
+	if n := utf8.RuneCountInString(got); n != maxChatLen {
+		t.Errorf("cleanChat kept %d runes, want %d", n, maxChatLen)
+	}
+}
@@ -2,22 +2,21 @@ package server
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/crazy"
 	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 const (
-	colP0Head  = lipgloss.Color("82")  // bright lime — player 0
-	colP0Body  = lipgloss.Color("40")  // medium green
-	colP1Head  = lipgloss.Color("39")  // bright cyan — player 1
-	colP1Body  = lipgloss.Color("37")  // teal
-	colP2Head  = lipgloss.Color("208") // bright orange — player 2
-	colP2Body  = lipgloss.Color("202") // deep orange
 	colFood    = lipgloss.Color("196") // red
 	colEmpty   = lipgloss.Color("236") // very dark grey
 	colBorder  = lipgloss.Color("241") // mid grey
@@ -28,16 +27,74 @@ const (
 	colWin     = lipgloss.Color("226") // bright yellow
 	colWaiting = lipgloss.Color("244")
 	colNote    = lipgloss.Color("248")
+	colBomb    = lipgloss.Color("201") // magenta, as in crazy mode
+	colWarning = lipgloss.Color("214")
 )
 
+// playerColor is one player's head and body colour.
+type playerColor struct {
+	head lipgloss.Color
+	body lipgloss.Color
+}
+
+// playerPalette holds a colour pair per player slot. Hues are spread around
+// the wheel and kept away from food red and bomb magenta so every snake stays
+// distinguishable on the dark board; head and body differ in brightness, and
+// the body glyph differs too. The array is sized by multi.MaxPlayers, so
+// raising the cap without adding colours fails to compile.
+var playerPalette = [multi.MaxPlayers]playerColor{
+	{head: "82", body: "40"},   // lime / green
+	{head: "39", body: "37"},   // cyan / teal
+	{head: "208", body: "202"}, // orange / deep orange
+	{head: "213", body: "169"}, // pink / rose
+	{head: "226", body: "178"}, // yellow / gold
+	{head: "141", body: "98"},  // lavender / purple
+	{head: "255", body: "247"}, // white / grey
+	{head: "33", body: "26"},   // blue / deep blue
+}
+
+// teamPalette gives each team a colour family, so teammates read as a group:
+// cool greens and blues against warm oranges and yellows. Team 1 avoids the
+// reds of food and death markers.
+var teamPalette = [multi.NumTeams][multi.MaxPlayers / multi.NumTeams]playerColor{
+	{
+		{head: "82", body: "40"},  // lime / green
+		{head: "39", body: "37"},  // cyan / teal
+		{head: "33", body: "26"},  // blue / deep blue
+		{head: "121", body: "72"}, // mint / sea green
+	},
+	{
+		{head: "208", body: "202"}, // orange / deep orange
+		{head: "226", body: "178"}, // yellow / gold
+		{head: "213", body: "169"}, // pink / rose
+		{head: "223", body: "180"}, // peach / tan
+	},
+}
+
+// teamNames names the teams after their colour families.
+var teamNames = [multi.NumTeams]string{"Green", "Orange"}
+
+// snakeColor returns the colours of player i's snake. In team games it comes
+// from the player's team family.
+func snakeColor(i int, teams bool) playerColor {
+	if teams {
+		family := teamPalette[multi.TeamOf(i)]
+		return family[(i/multi.NumTeams)%len(family)]
+	}
+	return playerPalette[i%len(playerPalette)]
+}
+
 type multiStyles struct {
 	board lipgloss.Style
 	info  lipgloss.Style
 
-	heads  [multi.MaxPlayers]lipgloss.Style
-	bodies [multi.MaxPlayers]lipgloss.Style
-	food   lipgloss.Style
-	empty  lipgloss.Style
+	heads   [multi.MaxPlayers]lipgloss.Style
+	bodies  [multi.MaxPlayers]lipgloss.Style
+	food    lipgloss.Style
+	bomb    lipgloss.Style
+	warning lipgloss.Style
+	empty   lipgloss.Style
+	dimmed  lipgloss.Style // snakes other than the followed one
 
 	title      lipgloss.Style
 	sectionLbl lipgloss.Style
@@ -50,7 +107,9 @@ type multiStyles struct {
 	note           lipgloss.Style
 }
 
-func newMultiStyles() multiStyles {
+// newMultiStyles builds the styles for a room; teams picks the team colour
+// families over the per-player palette.
+func newMultiStyles(teams bool) multiStyles {
 	panelW := 22
 
 	s := multiStyles{
@@ -64,8 +123,11 @@ func newMultiStyles() multiStyles {
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(colBorder),
 
-		food:  lipgloss.NewStyle().Foreground(colFood).Bold(true),
-		empty: lipgloss.NewStyle().Foreground(colEmpty),
+		food:    lipgloss.NewStyle().Foreground(colFood).Bold(true),
+		bomb:    lipgloss.NewStyle().Foreground(colBomb).Bold(true),
+		warning: lipgloss.NewStyle().Foreground(colWarning),
+		empty:   lipgloss.NewStyle().Foreground(colEmpty),
+		dimmed:  lipgloss.NewStyle().Foreground(colBorder),
 
 		title:      lipgloss.NewStyle().Width(panelW - 2).Align(lipgloss.Center).Bold(true).Foreground(colTitle),
 		sectionLbl: lipgloss.NewStyle().Foreground(colMuted).Width(panelW - 2),
@@ -79,35 +141,50 @@ func newMultiStyles() multiStyles {
 	}
 
 	// Per-player head / body styles.
-	headColors := [multi.MaxPlayers]lipgloss.Color{colP0Head, colP1Head, colP2Head}
-	bodyColors := [multi.MaxPlayers]lipgloss.Color{colP0Body, colP1Body, colP2Body}
 	for i := range s.heads {
-		s.heads[i] = lipgloss.NewStyle().Foreground(headColors[i]).Bold(true)
-		s.bodies[i] = lipgloss.NewStyle().Foreground(bodyColors[i])
+		c := snakeColor(i, teams)
+		s.heads[i] = lipgloss.NewStyle().Foreground(c.head).Bold(true)
+		s.bodies[i] = lipgloss.NewStyle().Foreground(c.body)
 	}
 
 	return s
 }
 
 type multiKeyMap struct {
-	Up        key.Binding
-	Down      key.Binding
-	Left      key.Binding
-	Right     key.Binding
-	Restart   key.Binding
-	Quit      key.Binding
-	ForceQuit key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Left       key.Binding
+	Right      key.Binding
+	Ready      key.Binding
+	Start      key.Binding
+	Kick       key.Binding
+	AddBot     key.Binding
+	BotLevel   key.Binding
+	Chat       key.Binding
+	Panel      key.Binding
+	Follow     key.Binding
+	FollowPrev key.Binding
+	Quit       key.Binding
+	ForceQuit  key.Binding
 }
 
 func newMultiKeyMap() multiKeyMap {
 	return multiKeyMap{
-		Up:        key.NewBinding(key.WithKeys("up", "w", "k"), key.WithHelp("↑/w", "up")),
-		Down:      key.NewBinding(key.WithKeys("down", "s", "j"), key.WithHelp("↓/s", "down")),
-		Left:      key.NewBinding(key.WithKeys("left", "a", "h"), key.WithHelp("←/a", "left")),
-		Right:     key.NewBinding(key.WithKeys("right", "d", "l"), key.WithHelp("→/d", "right")),
-		Restart:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "restart")),
-		Quit:      key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
-		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "force quit")),
+		Up:         key.NewBinding(key.WithKeys("up", "w", "k"), key.WithHelp("↑/w", "up")),
+		Down:       key.NewBinding(key.WithKeys("down", "s", "j"), key.WithHelp("↓/s", "down")),
+		Left:       key.NewBinding(key.WithKeys("left", "a", "h"), key.WithHelp("←/a", "left")),
+		Right:      key.NewBinding(key.WithKeys("right", "d", "l"), key.WithHelp("→/d", "right")),
+		Ready:      key.NewBinding(key.WithKeys(" ", "r"), key.WithHelp("space", "ready")),
+		Start:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "start (owner)")),
+		Kick:       key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "kick (owner)")),
+		AddBot:     key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "add bot (owner)")),
+		BotLevel:   key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "bot difficulty (owner)")),
+		Chat:       key.NewBinding(key.WithKeys("t", "/"), key.WithHelp("t", "chat")),
+		Panel:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "ratings / history")),
+		Follow:     key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "follow next")),
+		FollowPrev: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "follow previous")),
+		Quit:       key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+		ForceQuit:  key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "force quit")),
 	}
 }
 
@@ -115,30 +192,59 @@ var _ tea.Model = &SharedMultiGame{}
 
 // SharedMultiGame is the Bubble Tea model that runs inside each player's SSH
 // session.  It has no game logic of its own — it only renders incoming
-// GameStateMsg snapshots and forwards key presses to the room via sync.
+// GameStateMsg and RoomStateMsg snapshots and forwards key presses to the
+// room via sync.
 type SharedMultiGame struct {
 	player *Player
 	sync   chan tea.Msg
 	keys   multiKeyMap
 	styles multiStyles
+	teams  bool // whether styles were built for a team game
 
-	// Last received snapshot from the room.
+	// Last received snapshots from the room.
 	lastState *GameStateMsg
+	room      RoomStateMsg
 
 	// Per-session state.
-	myDead bool   // true once we've received our own death notification
-	note   string // last NoteMsg text
+	index    int           // our player index, -1 while queued or observing
+	myDead   bool          // true once we've received our own death notification
+	note     string        // last NoteMsg text
+	cursor   int           // seat selected for kicking (owner only)
+	botLevel ai.Difficulty // difficulty of the next bot the owner adds
+	focus    int           // player index the follow-cam is on, -1 for none
+
+	// Side panel shown between rounds, with the standings it last loaded.
+	panel     sidePanel
+	standings standings
+
+	// Chat scrollback and the message being typed, if any.
+	chat     []ChatMsg
+	input    textinput.Model
+	chatting bool
 
 	width  int
 	height int
 }
 
+// newSharedMultiGame builds the model for p. It is called with room.mu held.
 func newSharedMultiGame(p *Player, sync chan tea.Msg) *SharedMultiGame {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "say something"
+	input.CharLimit = maxChatLen
+	input.Width = 16
+
 	return &SharedMultiGame{
 		player: p,
 		sync:   sync,
 		keys:   newMultiKeyMap(),
-		styles: newMultiStyles(),
+		styles: newMultiStyles(p.room.Rules().Teams),
+		teams:  p.room.Rules().Teams,
+		index:  p.playerIndex,
+		focus:  -1,
+		input:  input,
+
+		botLevel: ai.Normal,
 	}
 }
 
@@ -158,52 +264,122 @@ func (m *SharedMultiGame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.note = string(msg)
 		return m, nil
 
+	case ChatMsg:
+		m.chat = append(m.chat, msg)
+		if len(m.chat) > chatScrollback {
+			m.chat = m.chat[len(m.chat)-chatScrollback:]
+		}
+		return m, nil
+
+	case RoomStateMsg:
+		// An admin may have switched the room to or from teams.
+		if teams := m.player.room.Rules().Teams; teams != m.teams {
+			m.styles, m.teams = newMultiStyles(teams), teams
+		}
+		m.room = msg
+		m.index = -1
+		for _, st := range msg.Seats {
+			if st.Key == m.player.key.String() {
+				m.index = st.Index
+			}
+		}
+		m.cursor = min(m.cursor, max(len(msg.Seats)-1, 0))
+		return m, nil
+
 	case GameStateMsg:
-		// Detect if this player died this tick.
-		if m.player.playerIndex >= 0 && !m.myDead {
+		// Detect if this player died, this tick or (after a reconnect or an
+		// elimination between ticks) earlier.
+		if m.index >= 0 && !m.myDead {
 			for _, idx := range msg.Died {
-				if idx == m.player.playerIndex {
+				if idx == m.index {
 					m.myDead = true
 					break
 				}
 			}
+			for _, snap := range msg.Players {
+				if snap.Index == m.index && !snap.Alive {
+					m.myDead = true
+				}
+			}
 		}
 		m.lastState = &msg
 		return m, nil
 
+	case standingsMsg:
+		m.standings = standings(msg)
+		return m, nil
+
 	case RestartMsg:
-		// Room restarted — clear local state and wait for first tick.
+		// New round — clear local state and wait for first tick.
 		m.lastState = nil
 		m.myDead = false
+		m.focus = -1
 		return m, nil
 
 	case tea.KeyMsg:
-		if key.Matches(msg, m.keys.ForceQuit) || key.Matches(msg, m.keys.Quit) {
+		if key.Matches(msg, m.keys.ForceQuit) {
 			return m, tea.Quit
 		}
+		if m.chatting {
+			return m.handleChatKey(msg)
+		}
+		if key.Matches(msg, m.keys.Quit) {
+			return m, tea.Quit
+		}
+		if key.Matches(msg, m.keys.Chat) {
+			m.chatting = true
+			return m, m.input.Focus()
+		}
 		return m.handleKey(msg)
 	}
 
+	if m.chatting {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
 	return m, nil
 }
 
-// handleKey routes keyboard input depending on current game state.
-func (m *SharedMultiGame) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Game over — only restart is meaningful.
-	if m.lastState != nil && m.lastState.Over {
-		if key.Matches(msg, m.keys.Restart) {
-			m.sendToRoom(RestartMsg{})
+// handleChatKey edits the chat line: enter sends it, esc discards it.
+func (m *SharedMultiGame) handleChatKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if text := strings.TrimSpace(m.input.Value()); text != "" {
+			m.sendToRoom(SayMsg{Key: m.player.key.String(), Text: text})
 		}
+		fallthrough
+	case tea.KeyEsc:
+		m.chatting = false
+		m.input.Reset()
+		m.input.Blur()
 		return m, nil
 	}
 
-	// Observers and dead players cannot steer.
-	if m.player.playerIndex < 0 || m.myDead {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// handleKey routes keyboard input depending on current game state.
+func (m *SharedMultiGame) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.room.Phase != PhasePlaying {
+		return m.handlePreRoundKey(msg)
+	}
+
+	// Observers and dead players cannot steer, but can follow a snake.
+	if m.spectating() {
+		switch {
+		case key.Matches(msg, m.keys.Follow), key.Matches(msg, m.keys.Right):
+			m.cycleFocus(1)
+		case key.Matches(msg, m.keys.FollowPrev), key.Matches(msg, m.keys.Left):
+			m.cycleFocus(-1)
+		}
 		return m, nil
 	}
 
 	// Active player — send direction changes to the room.
-	idx := m.player.playerIndex
+	idx := m.index
 	switch {
 	case key.Matches(msg, m.keys.Up):
 		m.sendToRoom(DirectionMsg{PlayerIndex: idx, Direction: int(snake.Up)})
@@ -217,6 +393,102 @@ func (m *SharedMultiGame) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// spectating reports whether this session is only watching the round.
+func (m *SharedMultiGame) spectating() bool {
+	return m.index < 0 || m.myDead
+}
+
+// cycleFocus moves the follow-cam delta snakes along, skipping dead ones
+// unless nobody is left alive.
+func (m *SharedMultiGame) cycleFocus(delta int) {
+	if m.lastState == nil || len(m.lastState.Players) == 0 {
+		return
+	}
+
+	var candidates []int
+	for _, snap := range m.lastState.Players {
+		if snap.Alive {
+			candidates = append(candidates, snap.Index)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, snap := range m.lastState.Players {
+			candidates = append(candidates, snap.Index)
+		}
+	}
+
+	pos := -1
+	for i, idx := range candidates {
+		if idx == m.focus {
+			pos = i
+		}
+	}
+	switch {
+	case pos < 0 && delta < 0:
+		pos = len(candidates) - 1
+	case pos < 0:
+		pos = 0
+	default:
+		pos = (pos + delta + len(candidates)) % len(candidates)
+	}
+	m.focus = candidates[pos]
+}
+
+// handlePreRoundKey handles ready-up and the owner's controls between rounds.
+func (m *SharedMultiGame) handlePreRoundKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	me := m.player.key.String()
+	switch {
+	case key.Matches(msg, m.keys.Ready):
+		m.sendToRoom(ReadyMsg{Key: me})
+	case key.Matches(msg, m.keys.Panel):
+		m.panel = (m.panel + 1) % sidePanelCount
+		return m, m.loadStandings()
+
+	case !m.isOwner():
+
+	case key.Matches(msg, m.keys.Start):
+		m.sendToRoom(StartMsg{Key: me})
+	case key.Matches(msg, m.keys.Up):
+		m.cursor = max(m.cursor-1, 0)
+	case key.Matches(msg, m.keys.Down):
+		m.cursor = min(m.cursor+1, max(len(m.room.Seats)-1, 0))
+	case key.Matches(msg, m.keys.Kick):
+		if order := m.seatOrder(); m.cursor < len(order) {
+			m.sendToRoom(KickMsg{Key: me, Target: m.room.Seats[order[m.cursor]].Key})
+		}
+	case key.Matches(msg, m.keys.AddBot):
+		m.sendToRoom(AddBotMsg{Key: me, Difficulty: m.botLevel})
+	case key.Matches(msg, m.keys.BotLevel):
+		m.botLevel = m.botLevel.Next()
+	}
+	return m, nil
+}
+
+// role describes this session's place in the room, from the latest snapshot.
+func (m *SharedMultiGame) role() string {
+	if m.index >= 0 {
+		return fmt.Sprintf("Player %d", m.index+1)
+	}
+	for _, q := range m.room.Queue {
+		if q.Key == m.player.key.String() {
+			return "Next round"
+		}
+	}
+	return "Observer"
+}
+
+func (m *SharedMultiGame) isOwner() bool {
+	me := m.player.key.String()
+	for _, list := range [][]SeatInfo{m.room.Seats, m.room.Queue} {
+		for _, st := range list {
+			if st.Key == me {
+				return st.Owner
+			}
+		}
+	}
+	return false
+}
+
 // sendToRoom sends a message to the room's sync channel without blocking.
 func (m *SharedMultiGame) sendToRoom(msg tea.Msg) {
 	go func() {
@@ -225,13 +497,16 @@ func (m *SharedMultiGame) sendToRoom(msg tea.Msg) {
 }
 
 func (m *SharedMultiGame) View() string {
-	if m.lastState == nil {
-		v := m.waitingView()
-		return v
+	if m.room.Phase != PhasePlaying || m.lastState == nil {
+		return m.preRoundView()
 	}
 
 	board := m.boardView()
-	info := m.infoView()
+	left := []string{m.infoView()}
+	if focus := m.focusView(); focus != "" {
+		left = append(left, focus)
+	}
+	info := lipgloss.JoinVertical(lipgloss.Left, append(left, m.chatView())...)
 
 	content := lipgloss.JoinHorizontal(lipgloss.Top, info, board)
 	if m.note != "" {
@@ -241,10 +516,11 @@ func (m *SharedMultiGame) View() string {
 		)
 	}
 
-	helpText := m.styles.sectionLbl.Render("↑↓←→/wasd move  q quit")
-	if m.lastState.Over {
-		helpText = m.styles.sectionLbl.Render("r restart  q quit")
+	help := "↑↓←→/wasd move  t chat  q quit"
+	if m.spectating() {
+		help = "tab/←→ follow  t chat  q quit"
 	}
+	helpText := m.styles.sectionLbl.Width(0).Render(help)
 	content = lipgloss.JoinVertical(lipgloss.Left, content, helpText)
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
@@ -254,10 +530,11 @@ func (m *SharedMultiGame) View() string {
 func (m *SharedMultiGame) boardView() string {
 	state := m.lastState
 
-	const (
-		innerW = snake.DefaultCols * 2 // two chars per cell
-		innerH = snake.DefaultRows
-	)
+	innerH := len(state.Matrix)
+	innerW := 0
+	if innerH > 0 {
+		innerW = len(state.Matrix[0]) * 2 // two chars per cell
+	}
 
 	var inner string
 	switch {
@@ -268,7 +545,7 @@ func (m *SharedMultiGame) boardView() string {
 		)
 	case m.myDead:
 		// Overlay on top of the live board so the spectator can still see action.
-		board := m.matrixStr(state.Matrix)
+		board := m.matrixStr(state.Matrix, state.Owners)
 		deadMsg := m.styles.overlayDead.Render(deadOverlay)
 		// Place the overlay centred over the board string.
 		inner = lipgloss.Place(innerW, innerH,
@@ -276,23 +553,245 @@ func (m *SharedMultiGame) boardView() string {
 			lipgloss.JoinVertical(lipgloss.Center, board, deadMsg),
 		)
 	default:
-		inner = m.matrixStr(state.Matrix)
+		inner = m.matrixStr(state.Matrix, state.Owners)
 	}
 
 	return m.styles.board.Render(inner)
 }
 
-// waitingView is shown before the game starts (fewer than 2 players).
-func (m *SharedMultiGame) waitingView() string {
-	role := m.player.roleString()
-	msg := fmt.Sprintf(
-		"  MULTI SNAKE\n\n  Connected as %s\n\n  Waiting for players...\n  (need at least 2 to start)",
-		role,
-	)
-	return lipgloss.Place(m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		m.styles.overlayWaiting.Render(msg),
-	)
+// preRoundView is shown before and between rounds: the seats with their
+// ready flags next to the last round's result, or the rules before the first.
+// Tournament match rooms show the live bracket instead.
+func (m *SharedMultiGame) preRoundView() string {
+	s := m.styles
+
+	var right string
+	switch {
+	case m.panel == panelRatings:
+		right = m.ratingsView()
+	case m.panel == panelHistory:
+		right = m.historyView()
+	case m.player.room.match != nil:
+		right = m.bracketView()
+	case m.lastState != nil && m.lastState.Over:
+		right = m.boardView()
+	default:
+		right = m.rulesView()
+	}
+	left := lipgloss.JoinVertical(lipgloss.Left, m.seatsView(), m.chatView())
+	content := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+
+	if m.note != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, s.note.Render(" "+m.note))
+	}
+
+	help := "space ready  tab ratings  t chat  q quit"
+	if m.isOwner() && m.player.room.match == nil {
+		help = "space ready  enter start  ↑↓ select  x kick  tab ratings  t chat  q quit\n" +
+			fmt.Sprintf("b add %s bot  v change difficulty", m.botLevel)
+	}
+	content = lipgloss.JoinVertical(lipgloss.Left, content, s.sectionLbl.Width(0).Render(help))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// seatsView lists seated and queued players with their ready state.
+func (m *SharedMultiGame) seatsView() string {
+	s := m.styles
+	room := m.room
+	divider := s.divider.Render(strings.Repeat("─", 18))
+	muted := lipgloss.NewStyle().Foreground(colMuted)
+
+	lines := []string{
+		s.title.Render("MULTI SNAKE"),
+		s.sectionLbl.Render(fmt.Sprintf("Room:  %s", m.player.room.id)),
+		s.sectionLbl.Render(fmt.Sprintf("You:   %s", m.role())),
+		divider,
+		s.sectionLbl.Render(fmt.Sprintf("Players %d/%d", len(room.Seats), m.player.room.Rules().MaxPlayers)),
+	}
+
+	owner := m.isOwner()
+	teams := m.player.room.Rules().Teams
+	order := m.seatOrder()
+	for pos, i := range order {
+		st := room.Seats[i]
+		if teams && (pos == 0 || multi.TeamOf(room.Seats[order[pos-1]].Index) != multi.TeamOf(st.Index)) {
+			lines = append(lines, m.teamHeader(multi.TeamOf(st.Index), ""))
+		}
+
+		cursor := " "
+		if owner && pos == m.cursor {
+			cursor = "▶"
+		}
+		crown := " "
+		if st.Owner {
+			crown = "★"
+		}
+		status := muted.Render("…")
+		switch {
+		case st.Bot:
+			status = muted.Render(st.Level.String())
+		case st.Away:
+			status = muted.Render("away")
+		case st.Ready:
+			status = lipgloss.NewStyle().Foreground(colTitle).Render("✓")
+		}
+		name := lipgloss.NewStyle().Foreground(m.color(st.Index).head).Render(truncate(st.Name, 11))
+		lines = append(lines, fmt.Sprintf("%s%s %-11s %s", cursor, crown, name, status))
+	}
+
+	if len(room.Queue) > 0 {
+		lines = append(lines, "", s.sectionLbl.Render("Next round"))
+		for _, q := range room.Queue {
+			lines = append(lines, muted.Render("   "+truncate(q.Name, 15)))
+		}
+	}
+	if room.Observers > 0 {
+		lines = append(lines, "", s.sectionLbl.Render(fmt.Sprintf("Watching: %d", room.Observers)))
+	}
+
+	lines = append(lines, divider, s.overlayOver.Padding(0).Render(m.statusLine()))
+
+	return s.info.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// statusLine says what the room is waiting for.
+func (m *SharedMultiGame) statusLine() string {
+	room := m.room
+	if room.Phase == PhaseCountdown {
+		return fmt.Sprintf("Starting in %d…", room.Countdown)
+	}
+	if len(room.Seats) < 2 {
+		return "Waiting for players"
+	}
+
+	waiting := 0
+	for _, st := range room.Seats {
+		if !st.Ready {
+			waiting++
+		}
+	}
+	return fmt.Sprintf("%d not ready yet", waiting)
+}
+
+// focusView shows the stats of the snake the follow-cam is on, or nothing if
+// it is off.
+func (m *SharedMultiGame) focusView() string {
+	s := m.styles
+
+	var snap PlayerSnapshot
+	found := false
+	for _, p := range m.lastState.Players {
+		if p.Index == m.focus {
+			snap, found = p, true
+		}
+	}
+	if !found {
+		return ""
+	}
+
+	rank := 1
+	for _, p := range m.lastState.Players {
+		if p.Score > snap.Score {
+			rank++
+		}
+	}
+
+	status := "alive"
+	switch {
+	case !snap.Alive:
+		status = "dead"
+	case snap.Away:
+		status = "away"
+	}
+
+	name := lipgloss.NewStyle().Foreground(m.color(snap.Index).head).Bold(true).Render(truncate(snap.Name, 20))
+	lines := []string{
+		s.title.Render("FOLLOWING"),
+		name,
+		s.sectionLbl.Render(fmt.Sprintf("Status: %s", status)),
+		s.sectionLbl.Render(fmt.Sprintf("Score:  %d", snap.Score)),
+		s.sectionLbl.Render(fmt.Sprintf("Length: %d", snap.Length)),
+		s.sectionLbl.Render(fmt.Sprintf("Rank:   %d of %d", rank, len(m.lastState.Players))),
+	}
+	return s.info.Padding(0, 1).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// color returns the colours of player i's snake under the room's rules.
+func (m *SharedMultiGame) color(i int) playerColor {
+	return snakeColor(i, m.player.room.Rules().Teams)
+}
+
+// seatOrder returns indices into m.room.Seats in the order they are listed:
+// grouped by team in team games, as seated otherwise. The owner's cursor
+// moves through this order.
+func (m *SharedMultiGame) seatOrder() []int {
+	order := make([]int, len(m.room.Seats))
+	for i := range order {
+		order[i] = i
+	}
+	if m.player.room.Rules().Teams {
+		slices.SortStableFunc(order, func(a, b int) int {
+			return multi.TeamOf(m.room.Seats[a].Index) - multi.TeamOf(m.room.Seats[b].Index)
+		})
+	}
+	return order
+}
+
+// teamHeader is the heading line of a team's group of players, in the team's
+// colour, with extra (such as the team score) on the right.
+func (m *SharedMultiGame) teamHeader(team int, extra string) string {
+	name := fmt.Sprintf("%s team", teamNames[team])
+	head := lipgloss.NewStyle().Foreground(teamPalette[team][0].head).Bold(true).Render(name)
+	width := m.styles.sectionLbl.GetWidth() - lipgloss.Width(name)
+	return head + lipgloss.NewStyle().Width(width).Align(lipgloss.Right).Foreground(colScore).Render(extra)
+}
+
+// chatView renders the chat scrollback, newest at the bottom, and the input
+// line while composing.
+func (m *SharedMultiGame) chatView() string {
+	s := m.styles
+	width := s.info.GetWidth() - s.info.GetHorizontalPadding()
+
+	var lines []string
+	for _, c := range m.chat {
+		name := lipgloss.NewStyle().Foreground(c.Color).Bold(true).Render(c.Name + ":")
+		msg := lipgloss.NewStyle().Width(width).Render(name + " " + c.Text)
+		lines = append(lines, strings.Split(msg, "\n")...)
+	}
+	if len(lines) > chatLines {
+		lines = lines[len(lines)-chatLines:]
+	}
+	if len(lines) == 0 {
+		lines = []string{s.sectionLbl.Render("No messages yet")}
+	}
+
+	footer := s.sectionLbl.Render("t to chat")
+	if m.chatting {
+		footer = m.input.View()
+	}
+
+	parts := append([]string{s.title.Render("CHAT")}, lines...)
+	parts = append(parts, s.divider.Render(strings.Repeat("─", 18)), footer)
+	return s.info.Padding(0, 1).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// rulesView lists the room's rules.
+func (m *SharedMultiGame) rulesView() string {
+	s := m.styles
+	lines := []string{s.title.Render("RULES"), ""}
+	for _, line := range m.player.room.Rules().Describe() {
+		lines = append(lines, s.sectionLbl.Render(line))
+	}
+	return s.info.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
 }
 
 const deadOverlay = `
@@ -306,10 +805,16 @@ const deadOverlay = `
 
 func (m *SharedMultiGame) gameOverMsg(state *GameStateMsg) string {
 	var title string
-	switch state.Winner {
-	case -1:
+	switch {
+	case state.TeamScores != nil && state.WinnerTeam < 0:
+		title = "DRAW — no team comes out on top!"
+	case state.TeamScores != nil && m.index >= 0 && multi.TeamOf(m.index) == state.WinnerTeam:
+		title = "🏆  YOUR TEAM WINS!"
+	case state.TeamScores != nil:
+		title = fmt.Sprintf("%s team wins!", teamNames[state.WinnerTeam])
+	case state.Winner == -1:
 		title = "DRAW — everyone died!"
-	case m.player.playerIndex:
+	case state.Winner == m.index:
 		title = "🏆  YOU WIN!"
 	default:
 		// Find winner name from snapshots.
@@ -333,15 +838,16 @@ func (m *SharedMultiGame) gameOverMsg(state *GameStateMsg) string {
 
   %s
 
-  Press R to restart  |  q to quit`, title)
+  Press space when ready for the next round  |  q to quit`, title)
 }
 
-// matrixStr converts a raw matrix into a styled two-char-per-cell string.
-func (m *SharedMultiGame) matrixStr(matrix snake.Matrix) string {
+// matrixStr converts a raw matrix and its owner layer into a styled
+// two-char-per-cell string.
+func (m *SharedMultiGame) matrixStr(matrix, owners snake.Matrix) string {
 	var sb strings.Builder
 	for row := range matrix {
 		for col := range matrix[row] {
-			sb.WriteString(m.renderCell(matrix[row][col]))
+			sb.WriteString(m.renderCell(matrix[row][col], owners[row][col]))
 		}
 		if row < len(matrix)-1 {
 			sb.WriteByte('\n')
@@ -350,28 +856,30 @@ func (m *SharedMultiGame) matrixStr(matrix snake.Matrix) string {
 	return sb.String()
 }
 
-// renderCell maps a matrix byte to a styled two-column string.
-func (m *SharedMultiGame) renderCell(cell byte) string {
+// renderCell maps a cell kind, and for snake cells its owner, to a styled
+// two-column string.
+func (m *SharedMultiGame) renderCell(cell, owner byte) string {
 	s := m.styles
+	if (cell == multi.CellHead || cell == multi.CellBody) && m.focus >= 0 && int(owner) != m.focus {
+		return s.dimmed.Render("▓▓")
+	}
+
 	switch cell {
-	// Player 0
-	case multi.HeadCells[0]:
-		return s.heads[0].Render("██")
-	case multi.BodyCells[0]:
-		return s.bodies[0].Render("▓▓")
-	// Player 1
-	case multi.HeadCells[1]:
-		return s.heads[1].Render("██")
-	case multi.BodyCells[1]:
-		return s.bodies[1].Render("▓▓")
-	// Player 2
-	case multi.HeadCells[2]:
-		return s.heads[2].Render("██")
-	case multi.BodyCells[2]:
-		return s.bodies[2].Render("▓▓")
-	// Food
-	case 'F':
+	// Snakes
+	case multi.CellHead:
+		return s.heads[int(owner)%len(s.heads)].Render("██")
+	case multi.CellBody:
+		return s.bodies[int(owner)%len(s.bodies)].Render("▓▓")
+	// Food and bombs
+	case multi.CellFood:
 		return s.food.Render("◆ ")
+	case multi.CellBomb:
+		return s.bomb.Render("✹ ")
+	case multi.CellBombWarning:
+		if (time.Now().UnixMilli()/crazy.BombBlinkPeriodMs)%2 == 0 {
+			return s.warning.Render("! ")
+		}
+		return s.empty.Render("· ")
 	default:
 		return s.empty.Render("· ")
 	}
@@ -385,20 +893,34 @@ func (m *SharedMultiGame) infoView() string {
 
 	header := s.title.Render("MULTI SNAKE")
 
-	// Player rows.
+	// Player rows, grouped under their team's total in team games. Crowded
+	// rooms drop the spacer line to keep the panel short.
+	players := state.Players
+	if state.TeamScores != nil {
+		players = slices.Clone(players)
+		slices.SortStableFunc(players, func(a, b PlayerSnapshot) int { return a.Team - b.Team })
+	}
 	var playerRows []string
-	playerColors := [multi.MaxPlayers]lipgloss.Color{colP0Head, colP1Head, colP2Head}
-	for _, snap := range state.Players {
+	for i, snap := range players {
+		if state.TeamScores != nil && (i == 0 || players[i-1].Team != snap.Team) {
+			playerRows = append(playerRows, m.teamHeader(snap.Team, fmt.Sprintf("%d pts", state.TeamScores[snap.Team])))
+		}
+
 		marker := "●"
-		style := lipgloss.NewStyle().Foreground(playerColors[snap.Index]).Bold(true)
+		style := lipgloss.NewStyle().Foreground(m.color(snap.Index).head).Bold(true)
 		if !snap.Alive {
 			marker = "○"
 			style = lipgloss.NewStyle().Foreground(colMuted)
 		}
 
 		tag := ""
-		if snap.Index == m.player.playerIndex {
+		switch {
+		case snap.Index == m.index:
 			tag = " ◀ you"
+		case snap.Away:
+			tag = " (away)"
+		case snap.Index == m.focus:
+			tag = " ◀ watch"
 		}
 
 		line := fmt.Sprintf("%s %s%s",
@@ -410,8 +932,10 @@ func (m *SharedMultiGame) infoView() string {
 		playerRows = append(playerRows,
 			s.sectionLbl.Render(line),
 			s.valueBig.Render(score),
-			"",
 		)
+		if len(state.Players) <= 4 {
+			playerRows = append(playerRows, "")
+		}
 	}
 
 	level := s.sectionLbl.Render(fmt.Sprintf("Level: %d", state.Level))
@@ -420,6 +944,17 @@ func (m *SharedMultiGame) infoView() string {
 	parts := []string{header, "\n", divider, "\n"}
 	parts = append(parts, playerRows...)
 	parts = append(parts, divider, "\n", level, room)
+	if state.Remaining > 0 {
+		parts = append(parts, s.sectionLbl.Render(fmt.Sprintf("Time:  %s", multi.ShortDuration(state.Remaining))))
+	}
+	if m.room.Observers > 0 {
+		parts = append(parts, s.sectionLbl.Render(fmt.Sprintf("Watching: %d", m.room.Observers)))
+	}
+
+	parts = append(parts, "", divider, "\n")
+	for _, line := range m.player.room.Rules().Describe() {
+		parts = append(parts, s.sectionLbl.Render(line))
+	}
 
 	body := lipgloss.JoinVertical(lipgloss.Left, parts...)
 	return s.info.Render(body)
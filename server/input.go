@@ -0,0 +1,73 @@
+package server
+
+import (
+	"io"
+	"sync"
+)
+
+// sessionInput reads an SSH session on a single goroutine and hands the bytes
+// to whichever Bubble Tea program currently owns the terminal.
+//
+// A session may run several programs one after another (the lobby, then a
+// room). Bubble Tea cannot interrupt a blocking Read on a non-file reader, so
+// giving each program the raw session would leave the previous program's read
+// loop waiting on it and swallow the first keystroke meant for the next one.
+type sessionInput struct {
+	data chan []byte
+}
+
+func newSessionInput(r io.Reader) *sessionInput {
+	in := &sessionInput{data: make(chan []byte)}
+	go in.pump(r)
+	return in
+}
+
+func (in *sessionInput) pump(r io.Reader) {
+	defer close(in.data)
+	for {
+		buf := make([]byte, 256)
+		n, err := r.Read(buf)
+		if n > 0 {
+			in.data <- buf[:n]
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// tap returns a reader for the next program. Closing it makes any pending Read
+// return io.EOF without consuming input, so the following tap sees it instead.
+func (in *sessionInput) tap() *inputTap {
+	return &inputTap{data: in.data, closed: make(chan struct{})}
+}
+
+type inputTap struct {
+	data    <-chan []byte
+	closed  chan struct{}
+	once    sync.Once
+	pending []byte
+}
+
+func (t *inputTap) Read(p []byte) (int, error) {
+	if len(t.pending) == 0 {
+		select {
+		case b, ok := <-t.data:
+			if !ok {
+				return 0, io.EOF
+			}
+			t.pending = b
+		case <-t.closed:
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(p, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+func (t *inputTap) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
@@ -0,0 +1,590 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+)
+
+const (
+	// lobbyRefreshInterval is how often the room listing is re-read.
+	lobbyRefreshInterval = time.Second
+	// maxRoomIDLen bounds room ids typed into the lobby.
+	maxRoomIDLen = 24
+	// maxPasswordLen bounds room passwords, well inside bcrypt's 72 bytes.
+	maxPasswordLen = 64
+)
+
+var roomIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// lobbyChoice is what the player picked in the lobby.
+type lobbyChoice struct {
+	room     *Room
+	spectate bool
+}
+
+type lobbyState int
+
+const (
+	lobbyBrowse   lobbyState = iota
+	lobbyPassword            // prompting for the selected room's password
+	lobbyCreate              // filling in the new room form
+)
+
+type lobbyRefreshMsg struct{}
+
+type lobbyKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Join      key.Binding
+	Spectate  key.Binding
+	Create    key.Binding
+	Refresh   key.Binding
+	NextField key.Binding
+	PrevField key.Binding
+	Back      key.Binding
+	Quit      key.Binding
+	ForceQuit key.Binding
+}
+
+func newLobbyKeyMap() lobbyKeyMap {
+	return lobbyKeyMap{
+		Up:        key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:      key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		Left:      key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "previous value")),
+		Right:     key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "next value")),
+		Join:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "join")),
+		Spectate:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "spectate")),
+		Create:    key.NewBinding(key.WithKeys("n", "c"), key.WithHelp("n", "new room")),
+		Refresh:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		NextField: key.NewBinding(key.WithKeys("tab", "down"), key.WithHelp("tab", "next field")),
+		PrevField: key.NewBinding(key.WithKeys("shift+tab", "up"), key.WithHelp("shift+tab", "previous field")),
+		Back:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		Quit:      key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "force quit")),
+	}
+}
+
+type lobbyStyles struct {
+	frame    lipgloss.Style
+	title    lipgloss.Style
+	subtitle lipgloss.Style
+	header   lipgloss.Style
+	row      lipgloss.Style
+	selected lipgloss.Style
+	playing  lipgloss.Style
+	waiting  lipgloss.Style
+	label    lipgloss.Style
+	err      lipgloss.Style
+	help     lipgloss.Style
+}
+
+func newLobbyStyles() lobbyStyles {
+	return lobbyStyles{
+		frame: lipgloss.NewStyle().
+			Padding(1, 2).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colBorder),
+		title:    lipgloss.NewStyle().Bold(true).Foreground(colTitle),
+		subtitle: lipgloss.NewStyle().Foreground(colMuted),
+		header:   lipgloss.NewStyle().Foreground(colMuted).Bold(true),
+		row:      lipgloss.NewStyle().Foreground(colNote),
+		selected: lipgloss.NewStyle().Foreground(colScore).Bold(true),
+		playing:  lipgloss.NewStyle().Foreground(colWarning),
+		waiting:  lipgloss.NewStyle().Foreground(colTitle),
+		label:    lipgloss.NewStyle().Foreground(colMuted).Width(10),
+		err:      lipgloss.NewStyle().Foreground(colDead),
+		help:     lipgloss.NewStyle().Foreground(colMuted),
+	}
+}
+
+var _ tea.Model = &lobbyModel{}
+
+// lobbyModel is the Bubble Tea model shown to sessions that connect without a
+// room id. It lists the server's rooms and lets the player join, spectate or
+// create one. Once a room is picked the program quits and the session is
+// handed over to that room.
+type lobbyModel struct {
+	srv    *Server
+	user   string
+	input  io.Closer // released on quit so the room's program gets the next key
+	keys   lobbyKeyMap
+	styles lobbyStyles
+
+	rooms  []RoomInfo
+	cursor int
+	state  lobbyState
+	err    string
+
+	// Password prompt for a locked room.
+	target   RoomInfo
+	spectate bool
+	password textinput.Model
+
+	// New room form: the text fields followed by the rule rows.
+	fields []textinput.Model
+	rules  []ruleOption
+	focus  int
+
+	choice *lobbyChoice
+
+	width  int
+	height int
+}
+
+func newLobbyModel(srv *Server, user string, input io.Closer) *lobbyModel {
+	pw := textinput.New()
+	pw.Prompt = ""
+	pw.Placeholder = "room password"
+	pw.EchoMode = textinput.EchoPassword
+	pw.CharLimit = maxPasswordLen
+
+	id := textinput.New()
+	id.Prompt = ""
+	id.Placeholder = "leave blank for a random id"
+	id.CharLimit = maxRoomIDLen
+
+	newPw := textinput.New()
+	newPw.Prompt = ""
+	newPw.Placeholder = "optional"
+	newPw.EchoMode = textinput.EchoPassword
+	newPw.CharLimit = maxPasswordLen
+
+	return &lobbyModel{
+		srv:      srv,
+		user:     user,
+		input:    input,
+		keys:     newLobbyKeyMap(),
+		styles:   newLobbyStyles(),
+		rooms:    srv.ListRooms(),
+		password: pw,
+		fields:   []textinput.Model{id, newPw},
+	}
+}
+
+func lobbyRefreshCmd() tea.Cmd {
+	return tea.Tick(lobbyRefreshInterval, func(time.Time) tea.Msg {
+		return lobbyRefreshMsg{}
+	})
+}
+
+func (m *lobbyModel) Init() tea.Cmd {
+	return lobbyRefreshCmd()
+}
+
+func (m *lobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case lobbyRefreshMsg:
+		m.refresh()
+		return m, lobbyRefreshCmd()
+
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keys.ForceQuit) {
+			return m, m.quit()
+		}
+
+		switch m.state {
+		case lobbyPassword:
+			return m.updatePassword(msg)
+		case lobbyCreate:
+			return m.updateCreate(msg)
+		default:
+			return m.updateBrowse(msg)
+		}
+	}
+
+	return m, nil
+}
+
+// refresh re-reads the room list, keeping the cursor on the same room.
+func (m *lobbyModel) refresh() {
+	selected := ""
+	if m.cursor < len(m.rooms) {
+		selected = m.rooms[m.cursor].ID
+	}
+
+	m.rooms = m.srv.ListRooms()
+	m.cursor = 0
+	for i, info := range m.rooms {
+		if info.ID == selected {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+func (m *lobbyModel) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, m.quit()
+
+	case key.Matches(msg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case key.Matches(msg, m.keys.Down):
+		if m.cursor < len(m.rooms)-1 {
+			m.cursor++
+		}
+
+	case key.Matches(msg, m.keys.Refresh):
+		m.err = ""
+		m.refresh()
+
+	case key.Matches(msg, m.keys.Create):
+		m.err = ""
+		m.state = lobbyCreate
+		m.focus = 0
+		for i := range m.fields {
+			m.fields[i].Reset()
+			m.fields[i].Blur()
+		}
+		m.rules = newRuleOptions()
+		return m, m.fields[0].Focus()
+
+	case key.Matches(msg, m.keys.Join), key.Matches(msg, m.keys.Spectate):
+		if len(m.rooms) == 0 {
+			return m, nil
+		}
+		m.err = ""
+		m.target = m.rooms[m.cursor]
+		m.spectate = key.Matches(msg, m.keys.Spectate)
+
+		if m.target.Locked {
+			m.state = lobbyPassword
+			m.password.Reset()
+			return m, m.password.Focus()
+		}
+		return m, m.join("")
+	}
+
+	return m, nil
+}
+
+func (m *lobbyModel) updatePassword(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = lobbyBrowse
+		m.password.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Join):
+		return m, m.join(m.password.Value())
+	}
+
+	var cmd tea.Cmd
+	m.password, cmd = m.password.Update(msg)
+	return m, cmd
+}
+
+func (m *lobbyModel) updateCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.state = lobbyBrowse
+		m.err = ""
+		return m, nil
+
+	case key.Matches(msg, m.keys.NextField):
+		return m, m.focusField(m.focus + 1)
+
+	case key.Matches(msg, m.keys.PrevField):
+		return m, m.focusField(m.focus - 1)
+
+	case key.Matches(msg, m.keys.Join):
+		return m, m.create()
+	}
+
+	// Rule rows only cycle their value.
+	if m.focus >= len(m.fields) {
+		opt := &m.rules[m.focus-len(m.fields)]
+		switch {
+		case key.Matches(msg, m.keys.Left):
+			opt.cycle(-1)
+		case key.Matches(msg, m.keys.Right):
+			opt.cycle(1)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.fields[m.focus], cmd = m.fields[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m *lobbyModel) focusField(i int) tea.Cmd {
+	if m.focus < len(m.fields) {
+		m.fields[m.focus].Blur()
+	}
+
+	n := len(m.fields) + len(m.rules)
+	m.focus = (i + n) % n
+	if m.focus < len(m.fields) {
+		return m.fields[m.focus].Focus()
+	}
+	return nil
+}
+
+// join validates the chosen room and, if it is still open and the password
+// matches, finishes the lobby.
+func (m *lobbyModel) join(password string) tea.Cmd {
+	room := m.srv.FindRoom(m.target.ID)
+	if room == nil {
+		m.state = lobbyBrowse
+		m.err = fmt.Sprintf("Room %q has closed.", m.target.ID)
+		m.refresh()
+		return nil
+	}
+
+	if !room.CheckPassword(password) {
+		m.err = "Incorrect room password."
+		m.password.Reset()
+		return nil
+	}
+
+	if m.spectate && room.Info().Observers >= MaxSpectators {
+		m.state = lobbyBrowse
+		m.err = fmt.Sprintf("Room %q has no room for more spectators.", m.target.ID)
+		m.refresh()
+		return nil
+	}
+
+	m.choice = &lobbyChoice{room: room, spectate: m.spectate}
+	return m.quit()
+}
+
+// create registers a new room from the form and joins it.
+func (m *lobbyModel) create() tea.Cmd {
+	id := strings.TrimSpace(m.fields[0].Value())
+	password := m.fields[1].Value()
+
+	if id == "" {
+		id = m.randomRoomID()
+	}
+	if !roomIDPattern.MatchString(id) {
+		m.err = "Room ids may only contain letters, digits, '-' and '_'."
+		return nil
+	}
+
+	rules := rulesFrom(m.rules)
+	if err := rules.Validate(); err != nil {
+		m.err = "Invalid rules: " + err.Error() + "."
+		return nil
+	}
+
+	room, err := m.srv.CreateRoom(id, password, rules)
+	if errors.Is(err, ErrRoomExists) {
+		m.err = fmt.Sprintf("Room %q already exists.", id)
+		return nil
+	}
+	if err != nil {
+		m.err = "Can't create room: " + err.Error() + "."
+		return nil
+	}
+
+	m.choice = &lobbyChoice{room: room}
+	return m.quit()
+}
+
+func (m *lobbyModel) randomRoomID() string {
+	for {
+		id := fmt.Sprintf("room-%04d", rand.IntN(10000))
+		if m.srv.FindRoom(id) == nil {
+			return id
+		}
+	}
+}
+
+func (m *lobbyModel) quit() tea.Cmd {
+	if m.input != nil {
+		_ = m.input.Close()
+	}
+	return tea.Quit
+}
+
+func (m *lobbyModel) View() string {
+	s := m.styles
+
+	var body string
+	switch m.state {
+	case lobbyPassword:
+		body = m.passwordView()
+	case lobbyCreate:
+		body = m.createView()
+	default:
+		body = m.roomsView()
+	}
+
+	parts := []string{
+		s.title.Render("GOSNAKE LOBBY"),
+		s.subtitle.Render(fmt.Sprintf("Signed in as %s · %d room(s) open", m.user, len(m.rooms))),
+		"",
+		body,
+	}
+	if m.err != "" {
+		parts = append(parts, "", s.err.Render(m.err))
+	}
+	parts = append(parts, "", s.help.Render(m.helpText()))
+
+	content := s.frame.Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+func (m *lobbyModel) roomsView() string {
+	s := m.styles
+	if len(m.rooms) == 0 {
+		return s.row.Render("No rooms yet — press n to create one.")
+	}
+
+	const rowFmt = "%-2s%-*s  %-8s  %-8s  %-8s  %-8s  %s"
+	lines := []string{
+		s.header.Render(fmt.Sprintf(rowFmt, "", maxRoomIDLen, "ROOM", "PLAYERS", "WATCHING", "STATUS", "MODE", "")),
+	}
+
+	for i, info := range m.rooms {
+		status := s.waiting.Render(fmt.Sprintf("%-8s", "waiting"))
+		if info.Started {
+			status = s.playing.Render(fmt.Sprintf("%-8s", "playing"))
+		}
+
+		lock := ""
+		if info.Locked {
+			lock = "🔒"
+		}
+
+		cursor, style := "", s.row
+		if i == m.cursor {
+			cursor, style = "▶", s.selected
+		}
+
+		left := style.Render(fmt.Sprintf("%-2s%-*s  %-8s  %-8d  ",
+			cursor, maxRoomIDLen, info.ID,
+			fmt.Sprintf("%d/%d", info.Players, info.MaxPlayers),
+			info.Observers,
+		))
+		right := style.Render(fmt.Sprintf("  %-8s  %s", info.Mode, lock))
+		lines = append(lines, left+status+right)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m *lobbyModel) passwordView() string {
+	s := m.styles
+	action := "Join"
+	if m.spectate {
+		action = "Spectate"
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		s.row.Render(fmt.Sprintf("%s room %q", action, m.target.ID)),
+		"",
+		s.label.Render("Password")+m.password.View(),
+	)
+}
+
+func (m *lobbyModel) createView() string {
+	s := m.styles
+	labels := []string{"Room id", "Password"}
+
+	lines := []string{s.row.Render("Create a new room"), ""}
+	for i, field := range m.fields {
+		label := s.label.Render(labels[i])
+		if i == m.focus {
+			label = s.label.Foreground(colScore).Render(labels[i])
+		}
+		lines = append(lines, label+field.View())
+	}
+
+	lines = append(lines, "")
+	for i, opt := range m.rules {
+		label := s.label.Render(opt.label)
+		value := s.row.Render("  " + opt.value())
+		if len(m.fields)+i == m.focus {
+			label = s.label.Foreground(colScore).Render(opt.label)
+			value = s.selected.Render("◀ " + opt.value() + " ▶")
+		}
+		lines = append(lines, label+value)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (m *lobbyModel) helpText() string {
+	switch m.state {
+	case lobbyPassword:
+		return "enter confirm  esc back"
+	case lobbyCreate:
+		return "tab/↑↓ field  ←→ change rule  enter create  esc back"
+	default:
+		return "↑↓ select  enter join  s spectate  n new room  r refresh  q quit"
+	}
+}
+
+// runLobby shows the lobby on s until the player picks a room or quits. It
+// returns nil when the player quit without choosing.
+func runLobby(srv *Server, s ssh.Session, in *inputTap) (*lobbyChoice, error) {
+	m := newLobbyModel(srv, s.User(), in)
+
+	ptyInfo, _, _ := s.Pty()
+	m.width = ptyInfo.Window.Width
+	m.height = ptyInfo.Window.Height
+
+	if err := runOnSession(s, m, in); err != nil {
+		return nil, err
+	}
+	return m.choice, nil
+}
+
+// runOnSession runs a full-screen program on s, reading keys from in, until it
+// quits or the session ends.
+func runOnSession(s ssh.Session, m tea.Model, in io.Reader) error {
+	_, wchan, _ := s.Pty()
+
+	prog := tea.NewProgram(
+		m,
+		tea.WithAltScreen(),
+		tea.WithInput(in),
+		tea.WithOutput(s),
+	)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case w, ok := <-wchan:
+				if !ok {
+					return
+				}
+				prog.Send(tea.WindowSizeMsg{Width: w.Width, Height: w.Height})
+			case <-s.Context().Done():
+				prog.Kill()
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if _, err := prog.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+)
+
+const (
+	// quickMaxPlayers is the size of a full quick match group.
+	quickMaxPlayers = 4
+	// quickGather is how long the longest-waiting player waits for a full
+	// group before a smaller match of two or more is started.
+	quickGather = 15 * time.Second
+	// quickInterval is how often the queue is checked for a match.
+	quickInterval = time.Second
+	// quickRecentWaits is how many past waits the wait estimate averages.
+	quickRecentWaits = 20
+)
+
+// errAlreadyQueued is returned when a key joins the queue twice.
+var errAlreadyQueued = errors.New("you are already in the matchmaking queue")
+
+// ticket is one player waiting in the matchmaking queue.
+type ticket struct {
+	key     string
+	name    string
+	winRate float64
+	joined  time.Time
+	found   chan *Room // receives the matched room, or is closed on cancel
+}
+
+// matchmaker groups queued players into new rooms. Groups prefer players with
+// similar win rates; the queue is checked once every quickInterval.
+type matchmaker struct {
+	srv *Server
+
+	mu      sync.Mutex
+	waiting []*ticket       // in join order
+	waits   []time.Duration // recent time-to-match, for the estimate
+
+	done chan struct{}
+}
+
+func newMatchmaker(srv *Server) *matchmaker {
+	mm := &matchmaker{srv: srv, done: make(chan struct{})}
+	go mm.run()
+	return mm
+}
+
+func (mm *matchmaker) run() {
+	ticker := time.NewTicker(quickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-mm.done:
+			return
+		case now := <-ticker.C:
+			mm.match(now)
+		}
+	}
+}
+
+// stop ends the matching loop; players still waiting are sent away.
+func (mm *matchmaker) stop() {
+	close(mm.done)
+
+	mm.mu.Lock()
+	for _, t := range mm.waiting {
+		close(t.found)
+	}
+	mm.waiting = nil
+	mm.mu.Unlock()
+}
+
+// join adds a player to the queue.
+func (mm *matchmaker) join(key PublicKey, name string) (*ticket, error) {
+	winRate := 0.5
+	if mm.srv.matches != nil {
+		rating, err := mm.srv.matches.Rating(key.Fingerprint())
+		if err != nil {
+			log.Printf("failed to read rating for %s: %v", name, err)
+		} else if rating.Matches > 0 {
+			winRate = float64(rating.Wins) / float64(rating.Matches)
+		}
+	}
+
+	t := &ticket{
+		key:     key.String(),
+		name:    name,
+		winRate: winRate,
+		joined:  time.Now(),
+		found:   make(chan *Room, 1),
+	}
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for _, w := range mm.waiting {
+		if w.key == t.key {
+			return nil, errAlreadyQueued
+		}
+	}
+	mm.waiting = append(mm.waiting, t)
+	return t, nil
+}
+
+// leave takes t out of the queue. It reports false if t was already matched.
+func (mm *matchmaker) leave(t *ticket) bool {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	i := slices.Index(mm.waiting, t)
+	if i < 0 {
+		return false
+	}
+	mm.waiting = slices.Delete(mm.waiting, i, i+1)
+	close(t.found)
+	return true
+}
+
+// status returns how many players are waiting and how long a match usually
+// takes to form.
+func (mm *matchmaker) status() (waiting int, estimate time.Duration) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	if len(mm.waits) == 0 {
+		return len(mm.waiting), quickGather
+	}
+	var total time.Duration
+	for _, w := range mm.waits {
+		total += w
+	}
+	return len(mm.waiting), total / time.Duration(len(mm.waits))
+}
+
+// match forms as many groups as the queue allows and moves each into a new
+// room.
+func (mm *matchmaker) match(now time.Time) {
+	mm.mu.Lock()
+	var groups [][]*ticket
+	for {
+		group := mm.nextGroupLocked(now)
+		if group == nil {
+			break
+		}
+		groups = append(groups, group)
+	}
+	mm.mu.Unlock()
+
+	for _, group := range groups {
+		room := mm.srv.quickRoom()
+		log.Printf("matchmaking: %d players matched into room %s", len(group), room)
+		for _, t := range group {
+			t.found <- room
+		}
+	}
+}
+
+// nextGroupLocked takes the next group out of the queue, or returns nil if
+// none is ready. The longest-waiting player is always in the group; the rest
+// are the players whose win rates sit closest to theirs. Caller must hold
+// mm.mu.
+func (mm *matchmaker) nextGroupLocked(now time.Time) []*ticket {
+	if len(mm.waiting) < 2 {
+		return nil
+	}
+	oldest := mm.waiting[0]
+	if len(mm.waiting) < quickMaxPlayers && now.Sub(oldest.joined) < quickGather {
+		return nil
+	}
+	size := min(len(mm.waiting), quickMaxPlayers)
+
+	byRate := slices.Clone(mm.waiting)
+	slices.SortStableFunc(byRate, func(a, b *ticket) int {
+		switch {
+		case a.winRate < b.winRate:
+			return -1
+		case a.winRate > b.winRate:
+			return 1
+		}
+		return 0
+	})
+
+	// Pick the window of size players, containing the oldest ticket, with
+	// the narrowest spread of win rates.
+	at := slices.Index(byRate, oldest)
+	best := -1
+	for start := max(at-size+1, 0); start <= at && start+size <= len(byRate); start++ {
+		spread := byRate[start+size-1].winRate - byRate[start].winRate
+		if best < 0 || spread < byRate[best+size-1].winRate-byRate[best].winRate {
+			best = start
+		}
+	}
+	group := byRate[best : best+size]
+
+	mm.waiting = slices.DeleteFunc(mm.waiting, func(t *ticket) bool {
+		return slices.Contains(group, t)
+	})
+	for _, t := range group {
+		mm.waits = append(mm.waits, now.Sub(t.joined))
+	}
+	if len(mm.waits) > quickRecentWaits {
+		mm.waits = mm.waits[len(mm.waits)-quickRecentWaits:]
+	}
+	return group
+}
+
+// quickRoom creates a room with the classic rules under a fresh id.
+func (s *Server) quickRoom() *Room {
+	for {
+		id := fmt.Sprintf("quick-%04d", rand.IntN(10000))
+		room, err := s.CreateRoom(id, "", multi.DefaultRules())
+		if err == nil {
+			return room
+		}
+	}
+}
+
+// quickFoundMsg carries the room a queued player was matched into; nil when
+// the ticket was cancelled.
+type quickFoundMsg struct{ room *Room }
+
+type quickTickMsg struct{}
+
+type quickKeyMap struct {
+	Leave     key.Binding
+	ForceQuit key.Binding
+}
+
+// quickModel is the screen shown while a player waits in the matchmaking
+// queue.
+type quickModel struct {
+	mm     *matchmaker
+	ticket *ticket
+	input  io.Closer
+	keys   quickKeyMap
+	styles lobbyStyles
+
+	waiting  int
+	estimate time.Duration
+	room     *Room
+	left     bool // backed out; a match found meanwhile is ignored
+
+	width  int
+	height int
+}
+
+var _ tea.Model = &quickModel{}
+
+func (m *quickModel) Init() tea.Cmd {
+	found := m.ticket.found
+	return tea.Batch(
+		func() tea.Msg { return quickFoundMsg{room: <-found} },
+		m.tick(),
+	)
+}
+
+func (m *quickModel) tick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return quickTickMsg{} })
+}
+
+func (m *quickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case quickTickMsg:
+		m.waiting, m.estimate = m.mm.status()
+		return m, m.tick()
+
+	case quickFoundMsg:
+		if !m.left {
+			m.room = msg.room
+		}
+		return m, m.quit()
+
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keys.Leave) || key.Matches(msg, m.keys.ForceQuit) {
+			// If a match was made in the meantime, the room is simply left
+			// without this player.
+			m.mm.leave(m.ticket)
+			m.left = true
+			return m, m.quit()
+		}
+	}
+	return m, nil
+}
+
+func (m *quickModel) quit() tea.Cmd {
+	_ = m.input.Close()
+	return tea.Quit
+}
+
+func (m *quickModel) View() string {
+	s := m.styles
+	waited := time.Since(m.ticket.joined).Round(time.Second)
+
+	expect := "any moment now"
+	if left := m.estimate - waited; left > 0 {
+		expect = "about " + multi.ShortDuration(left.Round(time.Second))
+	}
+
+	lines := []string{
+		s.title.Render("QUICK MATCH"),
+		s.subtitle.Render(fmt.Sprintf("Looking for opponents for %s…", m.ticket.name)),
+		"",
+		s.label.Render("In queue") + s.row.Render(fmt.Sprint(m.waiting)),
+		s.label.Render("Waited") + s.row.Render(multi.ShortDuration(waited)),
+		s.label.Render("Expected") + s.row.Render(expect),
+		"",
+		s.help.Render(fmt.Sprintf("Matches are %d–%d players of similar win rates.", 2, quickMaxPlayers)),
+		s.help.Render("esc/q leave the queue"),
+	}
+
+	content := s.frame.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// runQuick queues the session for a quick match and waits on s until a room
+// is found or the player backs out. It returns nil if no room was found.
+func runQuick(srv *Server, s ssh.Session, in *inputTap) (*Room, error) {
+	if s.PublicKey() == nil {
+		return nil, fmt.Errorf("no public key — re-run with: ssh -i <key> ...")
+	}
+	t, err := srv.matchmaker.join(PublicKey{key: s.PublicKey()}, s.User())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &quickModel{
+		mm:     srv.matchmaker,
+		ticket: t,
+		input:  in,
+		keys: quickKeyMap{
+			Leave:     key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc/q", "leave the queue")),
+			ForceQuit: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")),
+		},
+		styles: newLobbyStyles(),
+	}
+	m.waiting, m.estimate = srv.matchmaker.status()
+
+	ptyInfo, _, _ := s.Pty()
+	m.width = ptyInfo.Window.Width
+	m.height = ptyInfo.Window.Height
+
+	err = runOnSession(s, m, in)
+	if m.room == nil {
+		srv.matchmaker.leave(t)
+	}
+	return m.room, err
+}
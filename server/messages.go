@@ -1,10 +1,31 @@
 package server
 
-import "github.com/HilthonTT/gosnake/pkg/snake"
+import (
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+	"github.com/charmbracelet/lipgloss"
+)
 
 // NoteMsg is a plain-text notification broadcast to all players in a room.
 type NoteMsg string
 
+// SayMsg is sent by a player's SharedMultiGame to the room's sync channel to
+// post a chat message. Text is raw user input; the room cleans it.
+type SayMsg struct {
+	Key  string // sender's public key
+	Text string
+}
+
+// ChatMsg is a cleaned chat message broadcast to everyone in the room.
+type ChatMsg struct {
+	Name  string
+	Color lipgloss.Color // sender's snake colour, muted for observers
+	Text  string
+}
+
 // DirectionMsg is sent by a player's SharedMultiGame to the room's sync
 // channel to queue a direction change.
 type DirectionMsg struct {
@@ -19,7 +40,9 @@ type PlayerSnapshot struct {
 	Name   string
 	Score  int
 	Alive  bool
+	Away   bool // dropped and within the reconnect grace period
 	Length int
+	Team   int // -1 outside team games
 }
 
 // GameStateMsg is broadcast by the room to every connected session after each
@@ -27,13 +50,90 @@ type PlayerSnapshot struct {
 // player's Bubble Tea program can render independently without touching shared
 // mutable state.
 type GameStateMsg struct {
-	Matrix  snake.Matrix
-	Players []PlayerSnapshot
-	Level   int
-	Over    bool
-	Winner  int   // -1 = draw, 0-2 = winning player index
-	Died    []int // player indices that died this tick (for death overlay)
+	Matrix    snake.Matrix // cell kinds, see multi.Cell*
+	Owners    snake.Matrix // player index of each snake cell
+	Players   []PlayerSnapshot
+	Level     int
+	Remaining time.Duration // time left under a time-limit rule, else 0
+	Over      bool
+	Winner    int   // -1 = draw, otherwise the winning player index
+	Died      []int // player indices that died this tick (for death overlay)
+
+	// Team games only: combined score per team and the winning team (-1 for
+	// a draw). TeamScores is nil outside team games.
+	TeamScores []int
+	WinnerTeam int
 }
 
-// RestartMsg is broadcast when any player triggers a restart.
+// RestartMsg is broadcast when a new round starts, so clients drop the last
+// round's state.
 type RestartMsg struct{}
+
+// ReadyMsg toggles a seated player's ready flag between rounds.
+type ReadyMsg struct {
+	Key string // sender's public key
+}
+
+// StartMsg asks the room to start the countdown without waiting for everyone
+// to be ready. Only honoured from the room owner.
+type StartMsg struct {
+	Key string
+}
+
+// KickMsg asks the room to remove Target. Only honoured from the room owner.
+type KickMsg struct {
+	Key    string
+	Target string // public key of the player to remove
+}
+
+// AddBotMsg asks the room to seat a bot. Only honoured from the room owner
+// between rounds.
+type AddBotMsg struct {
+	Key        string
+	Difficulty ai.Difficulty
+}
+
+// SeatInfo describes one seated or queued player.
+type SeatInfo struct {
+	Index int // player index, -1 while queued
+	Name  string
+	Key   string
+	Ready bool
+	Owner bool
+	Away  bool
+	Bot   bool
+	Level ai.Difficulty // bot difficulty, unused for people
+}
+
+// RoomStateMsg is broadcast whenever the seats, the queue or the phase change,
+// and once a second during the countdown.
+type RoomStateMsg struct {
+	Phase     RoomPhase
+	Seats     []SeatInfo
+	Queue     []SeatInfo
+	Observers int
+	Countdown int // number being shown while Phase == PhaseCountdown
+}
+
+// roomChangedMsg asks listen() to broadcast a fresh RoomStateMsg.
+type roomChangedMsg struct{}
+
+// adminKickMsg asks listen() to remove the bot called target, or the player
+// with that name or key fingerprint, on an admin's behalf. The outcome is
+// sent on reply.
+type adminKickMsg struct {
+	target string
+	reply  chan error
+}
+
+// setRulesMsg asks listen() to switch the room to new rules between rounds.
+// The outcome is sent on reply.
+type setRulesMsg struct {
+	rules multi.Rules
+	reply chan error
+}
+
+// leaveMsg tells listen() a session has left the room for good.
+type leaveMsg struct {
+	key string
+}
@@ -1,12 +1,16 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
@@ -60,6 +64,26 @@ func (rl *ipRateLimiter) limiterFor(ip string) *rate.Limiter {
 	return e.limiter
 }
 
+// ipStatus is one tracked address as shown to admins.
+type ipStatus struct {
+	ip       string
+	tokens   float64 // connections it could open right now
+	lastSeen time.Time
+}
+
+// status returns every tracked address, most recently seen first.
+func (rl *ipRateLimiter) status() []ipStatus {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	out := make([]ipStatus, 0, len(rl.entries))
+	for ip, e := range rl.entries {
+		out = append(out, ipStatus{ip: ip, tokens: e.limiter.Tokens(), lastSeen: e.lastSeen})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].lastSeen.After(out[j].lastSeen) })
+	return out
+}
+
 func (rl *ipRateLimiter) pruneLoop() {
 	ticker := time.NewTicker(ipLimiterTTL / 2)
 	defer ticker.Stop()
@@ -96,31 +120,63 @@ func perIPMiddleware(rl *ipRateLimiter) wish.Middleware {
 	}
 }
 
+// commands are the session commands; a room named after one can't be joined
+// by id.
+var commands = []string{"admin", "quick", "spectate", "tournament"}
+
 // multiMiddleware is the wish middleware that handles all incoming SSH sessions.
-// It parses the command arguments to find a room ID and optional password,
-// then assigns the session to the appropriate room.
+// Sessions that name a room are placed in it directly (creating it if
+// needed); sessions without a command are shown the lobby first.
 //
 // Connection format:
 //
-//	ssh <name>@<host> -p <port> -t <room-id> [room-password]
+//	ssh <name>@<host> -p <port> -t [spectate] [room-id] [room-password]
+//	ssh <name>@<host> -p <port> -t quick
+//	ssh <name>@<host> -p <port> -t tournament [command]
+//	ssh admin@<host> -p <port> -t admin [command]
 func multiMiddleware(srv *Server) wish.Middleware {
 	return func(sh ssh.Handler) ssh.Handler {
 		lipgloss.SetColorProfile(termenv.ANSI256)
 
 		return func(s ssh.Session) {
-			log.Printf("Hit 1")
-
 			_, _, active := s.Pty()
-			cmds := s.Command()
-
-			if !active || len(cmds) < 1 {
-				log.Printf("Hit 2")
+			if !active {
 				_, _ = s.Write([]byte(usage("A PTY is required — add the -t flag.")))
 				_ = s.Exit(1)
 				return
 			}
 
-			log.Printf("Hit 3")
+			cmds := s.Command()
+			if len(cmds) == 0 {
+				lobbySession(srv, s)
+				sh(s)
+				return
+			}
+			if cmds[0] == "quick" {
+				quickSession(srv, s)
+				sh(s)
+				return
+			}
+			if cmds[0] == "admin" {
+				adminSession(srv, s, cmds[1:])
+				sh(s)
+				return
+			}
+			if cmds[0] == "tournament" {
+				tournamentSession(srv, s, cmds[1:])
+				sh(s)
+				return
+			}
+
+			spectate := cmds[0] == "spectate"
+			if spectate {
+				cmds = cmds[1:]
+				if len(cmds) == 0 {
+					_, _ = s.Write([]byte(usage("spectate needs a room id.")))
+					_ = s.Exit(1)
+					return
+				}
+			}
 
 			roomID := cmds[0]
 			password := ""
@@ -128,46 +184,116 @@ func multiMiddleware(srv *Server) wish.Middleware {
 				password = cmds[1]
 			}
 
-			log.Printf("Hit 4")
-
-			// Find or create the room.
+			// Find or create the room. Spectators never create one.
 			room := srv.FindRoom(roomID)
 			if room == nil {
-				log.Printf("Hit 5")
-				log.Printf("room %q created with password %q", roomID, password)
-				room = srv.NewRoom(roomID, password)
+				if spectate {
+					_, _ = s.Write([]byte(usage(fmt.Sprintf("No room named %q.", roomID))))
+					_ = s.Exit(1)
+					return
+				}
+				var msg string
+				room, msg = createDirectRoom(srv, roomID, password)
+				if room == nil {
+					_, _ = s.Write([]byte(usage(msg)))
+					_ = s.Exit(1)
+					return
+				}
 			}
 
 			// Password check.
-			if room.password != password {
-				log.Printf("Hit 6")
+			if !room.CheckPassword(password) {
 				_, _ = s.Write([]byte(usage("Incorrect room password.")))
 				_ = s.Exit(1)
 				return
 			}
 
-			log.Printf("Hit 7")
+			playRoom(s, room, JoinOptions{Spectate: spectate})
+			sh(s)
+		}
+	}
+}
 
-			// Assign player slot.
-			p, err := room.AddPlayer(s)
-			if err != nil {
-				log.Printf("Hit 8")
-				_, _ = s.Write([]byte(err.Error() + "\n"))
-				_ = s.Exit(1)
-				return
-			}
+// createDirectRoom creates the room a session asked for by id. Another
+// session may create it first, in which case that room is joined instead. A
+// nil room comes with the reason it couldn't be created.
+func createDirectRoom(srv *Server, id, password string) (*Room, string) {
+	switch {
+	case len(id) > maxRoomIDLen:
+		return nil, fmt.Sprintf("Room ids are at most %d characters.", maxRoomIDLen)
+	case !roomIDPattern.MatchString(id):
+		return nil, "Room ids may only contain letters, digits, '-' and '_'."
+	case len(password) > maxPasswordLen:
+		return nil, fmt.Sprintf("Room passwords are at most %d characters.", maxPasswordLen)
+	}
 
-			log.Printf("Hit 9")
+	room, err := srv.CreateRoom(id, password, multi.DefaultRules())
+	switch {
+	case errors.Is(err, ErrRoomExists):
+		if room = srv.FindRoom(id); room == nil {
+			return nil, fmt.Sprintf("Room %q closed as it was being joined; try again.", id)
+		}
+		return room, ""
+	case errors.Is(err, errRoomReserved):
+		return nil, "That room is kept for a tournament match that isn't ready yet."
+	case err != nil:
+		return nil, "Can't create room: " + err.Error() + "."
+	}
 
-			log.Printf("%s joined room %q [%s]", s.User(), roomID, s.RemoteAddr())
-			p.StartGame()
-			log.Printf("%s left room %q [%s]", s.User(), roomID, s.RemoteAddr())
+	log.Printf("room %q created (password protected: %t)", id, password != "")
+	return room, ""
+}
 
-			log.Printf("Hit 10")
+// lobbySession runs the lobby and then moves the session into the room the
+// player picked, if any.
+func lobbySession(srv *Server, s ssh.Session) {
+	in := newSessionInput(s)
 
-			sh(s)
-		}
+	choice, err := runLobby(srv, s, in.tap())
+	if err != nil {
+		log.Printf("lobby error for %s: %v", s.User(), err)
+		_ = s.Exit(1)
+		return
 	}
+	if choice == nil {
+		return
+	}
+
+	playRoom(s, choice.room, JoinOptions{Spectate: choice.spectate, Input: in.tap()})
+}
+
+// quickSession queues the session for a quick match and then moves it into the
+// room it was matched into, if the player did not back out first.
+func quickSession(srv *Server, s ssh.Session) {
+	in := newSessionInput(s)
+
+	room, err := runQuick(srv, s, in.tap())
+	if err != nil {
+		log.Printf("matchmaking error for %s: %v", s.User(), err)
+		_, _ = s.Write([]byte(err.Error() + "\n"))
+		_ = s.Exit(1)
+		return
+	}
+	if room == nil {
+		_, _ = s.Write([]byte("Left the matchmaking queue.\n"))
+		return
+	}
+
+	playRoom(s, room, JoinOptions{Input: in.tap()})
+}
+
+// playRoom adds the session to room and blocks until it leaves.
+func playRoom(s ssh.Session, room *Room, opts JoinOptions) {
+	p, err := room.AddPlayer(s, opts)
+	if err != nil {
+		_, _ = s.Write([]byte(err.Error() + "\n"))
+		_ = s.Exit(1)
+		return
+	}
+
+	log.Printf("%s joined room %q [%s]", s.User(), room.id, s.RemoteAddr())
+	p.StartGame()
+	log.Printf("%s left room %q [%s]", s.User(), room.id, s.RemoteAddr())
 }
 
 func usage(reason string) string {
@@ -175,12 +301,21 @@ func usage(reason string) string {
 		"GoSnake Multiplayer",
 		"",
 		"Usage:",
-		"  ssh <name>@<host> -p <port> -t <room-id> [room-password]",
+		"  ssh <name>@<host> -p <port> -t                                open the lobby",
+		"  ssh <name>@<host> -p <port> -t <room-id> [password]           join or create a room",
+		"  ssh <name>@<host> -p <port> -t spectate <room-id> [password]  watch a room",
+		"  ssh <name>@<host> -p <port> -t quick                          find a match automatically",
+		"  ssh <name>@<host> -p <port> -t tournament                     run or follow a tournament",
+		"  ssh admin@<host> -p <port> -t admin                           server admin (admin keys only)",
 		"",
 		"Notes:",
-		"  • Up to 3 players per room; extras join as observers.",
+		"  • Up to 8 players per room; late joiners are queued for the next round.",
+		"  • Up to 16 spectators per room; press tab to follow a snake.",
 		"  • The first player to create a room sets its password.",
-		"  • The game starts automatically once 2+ players have joined.",
+		"  • Rooms created from the lobby can change the rules; others play classic.",
+		"  • A round starts once 2+ players are ready, or when the room owner starts it.",
+		"  • Playing alone? The room owner can press b to add bots to empty seats.",
+		"  • Dropped mid-match? Reconnect with the same key within 30s to keep your snake.",
 		"",
 	}
 	if reason != "" {
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+)
+
+func TestCreateDirectRoom(t *testing.T) {
+	srv, err := NewServer(filepath.Join(t.TempDir(), "host_key"), "127.0.0.1", 0, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.tournaments.stop()
+
+	srv.tournaments.mu.Lock()
+	srv.tournaments.all["cup"] = &data.Tournament{ID: "cup"}
+	srv.tournaments.mu.Unlock()
+
+	existing, err := srv.CreateRoom("taken", "", multi.DefaultRules())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer existing.Close()
+
+	tests := []struct {
+		name     string
+		id       string
+		password string
+		want     *Room // nil means any new room
+		wantErr  string
+	}{
+		{name: "new room", id: "fresh"},
+		{name: "locked room", id: "locked", password: "hunter2"},
+		{name: "created concurrently", id: "taken", want: existing},
+		{name: "too long", id: strings.Repeat("a", maxRoomIDLen+1), wantErr: "at most"},
+		{name: "bad characters", id: "no spaces", wantErr: "letters, digits"},
+		{name: "long password", id: "locked", password: strings.Repeat("p", maxPasswordLen+1), wantErr: "passwords"},
+		{name: "tournament match", id: "cup-1", wantErr: "tournament"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room, msg := createDirectRoom(srv, tt.id, tt.password)
+			if tt.wantErr != "" {
+				if room != nil || !strings.Contains(msg, tt.wantErr) {
+					t.Fatalf("createDirectRoom(%q) = %v, %q; want refusal mentioning %q", tt.id, room, msg, tt.wantErr)
+				}
+				return
+			}
+
+			if room == nil {
+				t.Fatalf("createDirectRoom(%q) refused: %s", tt.id, msg)
+			}
+			if tt.want == nil && (!room.CheckPassword(tt.password) || (tt.password != "" && room.CheckPassword(""))) {
+				t.Errorf("createDirectRoom(%q) did not lock the room with its password", tt.id)
+			}
+			if tt.want != nil && room != tt.want {
+				t.Errorf("createDirectRoom(%q) replaced the existing room", tt.id)
+			}
+			if tt.want == nil {
+				room.Close()
+			}
+		})
+	}
+}
@@ -7,17 +7,22 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/ssh"
+	"golang.org/x/time/rate"
 )
 
 // Player represents one connected SSH session inside a room.
-// playerIndex == -1 means the session is an observer.
+// playerIndex == -1 means the session is queued or an observer. playerIndex,
+// queued and superseded are guarded by room.mu.
 type Player struct {
 	room        *Room
 	session     ssh.Session
 	program     *tea.Program
 	game        *SharedMultiGame
 	playerIndex int
+	queued      bool
+	superseded  bool // a newer session with the same key took over
 	key         PublicKey
+	chat        *rate.Limiter
 	once        sync.Once
 	wchan       <-chan ssh.Window
 }
@@ -28,10 +33,27 @@ func (p *Player) String() string {
 }
 
 func (p *Player) roleString() string {
-	if p.playerIndex >= 0 {
+	p.room.mu.RLock()
+	defer p.room.mu.RUnlock()
+	return p.roleLocked()
+}
+
+// roleLocked is roleString for callers already holding room.mu.
+func (p *Player) roleLocked() string {
+	switch {
+	case p.playerIndex >= 0:
 		return fmt.Sprintf("Player %d", p.playerIndex+1)
+	case p.queued:
+		return "Queued for next round"
+	default:
+		return "Observer"
 	}
-	return "Observer"
+}
+
+func (p *Player) isSuperseded() bool {
+	p.room.mu.RLock()
+	defer p.room.mu.RUnlock()
+	return p.superseded
 }
 
 // Send dispatches a Bubble Tea message to this player's program.
@@ -61,7 +83,9 @@ func (p *Player) closeOnce() {
 		p.session.Close()
 
 		p.room.mu.Lock()
-		delete(p.room.players, p.key.String())
+		if p.room.players[p.key.String()] == p {
+			delete(p.room.players, p.key.String())
+		}
 		p.room.mu.Unlock()
 	})
 }
@@ -100,11 +124,27 @@ func (p *Player) StartGame() {
 
 	defer func() {
 		log.Printf("[StartGame] %s deferred cleanup", p.session.User())
-		select {
-		case p.room.sync <- NoteMsg(fmt.Sprintf("%s left the room", p)):
-		default:
+		// A newer session took over; the seat is no longer this one's.
+		if p.isSuperseded() {
+			p.closeOnce()
+			return
+		}
+		// A dropped connection (rather than pressing q) may come back.
+		dropped := p.session.Context().Err() != nil
+		held := dropped && p.room.holdSlot(p)
+		if !held {
+			select {
+			case p.room.sync <- NoteMsg(fmt.Sprintf("%s left the room", p)):
+			default:
+			}
 		}
 		p.closeOnce()
+		if !held {
+			select {
+			case p.room.sync <- leaveMsg{key: p.key.String()}:
+			default:
+			}
+		}
 	}()
 
 	log.Printf("[StartGame] calling Run() for %s", p.session.User())
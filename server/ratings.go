@@ -0,0 +1,218 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// matchResult builds the rated result of the round that just ended. Bots are
+// left out and placed players are ranked among the people only; in team games
+// the winning team shares first place. Only called from listen(), before
+// endRound frees the seats of players who left.
+func (r *Room) matchResult() (data.Match, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	players := r.game.Players()
+	if len(players) != len(r.seats) {
+		return data.Match{}, false
+	}
+
+	m := data.Match{Room: r.id, Mode: r.Rules().Name()}
+	winner := -1 // position of the winner in m.Players
+	var teams []int
+	for i, p := range players {
+		if r.seats[i].bot {
+			continue
+		}
+		teams = append(teams, p.Team)
+		if i == r.game.Winner() {
+			winner = len(m.Players)
+		}
+		m.Players = append(m.Players, data.MatchPlayer{
+			Fingerprint: r.seats[i].fingerprint,
+			Name:        r.seats[i].name,
+			Score:       p.Score,
+			Length:      len(p.Points),
+		})
+	}
+	if len(m.Players) < 2 {
+		return data.Match{}, false
+	}
+
+	if r.Rules().Teams {
+		for i := range m.Players {
+			m.Players[i].Place = 1
+			if won := r.game.WinningTeam(); won >= 0 && teams[i] != won {
+				m.Players[i].Place = 2
+			}
+		}
+		return m, true
+	}
+
+	// The winner comes first; everyone else is placed by score.
+	order := make([]int, len(m.Players))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ia, ib := order[a], order[b]
+		if (ia == winner) != (ib == winner) {
+			return ia == winner
+		}
+		return m.Players[ia].Score > m.Players[ib].Score
+	})
+	for pos, i := range order {
+		place := pos + 1
+		if pos > 0 {
+			prev := order[pos-1]
+			if prev != winner && m.Players[prev].Score == m.Players[i].Score {
+				place = m.Players[prev].Place
+			}
+		}
+		m.Players[i].Place = place
+	}
+
+	return m, true
+}
+
+// recordMatch stores a finished match and tells each player how their rating
+// moved. It runs on its own goroutine so a slow disk never stalls the room.
+func (r *Room) recordMatch(m data.Match) {
+	changes, err := r.matches.Record(m)
+	if err != nil {
+		log.Printf("failed to record match in room %s: %v", r, err)
+		return
+	}
+
+	notes := make(map[*Player]NoteMsg)
+	r.mu.RLock()
+	for _, c := range changes {
+		for _, p := range r.players {
+			if p.key.Fingerprint() == c.Fingerprint {
+				notes[p] = NoteMsg(fmt.Sprintf("Your rating: %d (%+d)", c.After, c.After-c.Before))
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	for p, note := range notes {
+		p.Send(note)
+	}
+}
+
+// sidePanel is what the right-hand side of the between-rounds view shows.
+type sidePanel int
+
+const (
+	panelRound   sidePanel = iota // the last round's board, or the rules
+	panelRatings                  // the server's top ratings
+	panelHistory                  // this player's recent matches
+	sidePanelCount
+)
+
+const (
+	// standingsRows is how many ratings or matches the side panel lists.
+	standingsRows = 10
+	// standingsWidth is the inner width of the ratings and history panels.
+	standingsWidth = 40
+)
+
+// standings is what the ratings and history panels show.
+type standings struct {
+	top     []data.PlayerRating
+	me      data.PlayerRating
+	history []data.MatchHistoryEntry
+	err     error
+}
+
+type standingsMsg standings
+
+// loadStandings reads the ratings for the current panel from the database.
+func (m *SharedMultiGame) loadStandings() tea.Cmd {
+	matches := m.player.room.matches
+	if matches == nil || m.panel == panelRound {
+		return nil
+	}
+	fingerprint := m.player.key.Fingerprint()
+
+	return func() tea.Msg {
+		var (
+			st  standings
+			err error
+		)
+		st.me, err = matches.Rating(fingerprint)
+		if err == nil {
+			st.top, err = matches.TopRatings(standingsRows)
+		}
+		if err == nil {
+			st.history, err = matches.History(fingerprint, standingsRows)
+		}
+		st.err = err
+		return standingsMsg(st)
+	}
+}
+
+// ratingsView lists the highest-rated players on the server.
+func (m *SharedMultiGame) ratingsView() string {
+	s := m.styles
+	label := s.sectionLbl.Width(standingsWidth)
+	lines := []string{s.title.Width(standingsWidth).Render("RATINGS"), ""}
+
+	switch {
+	case m.player.room.matches == nil:
+		lines = append(lines, label.Render("Ratings are off on this server."))
+	case m.standings.err != nil:
+		lines = append(lines, label.Render("Ratings are unavailable."))
+	case len(m.standings.top) == 0:
+		lines = append(lines, label.Render("No rated matches yet."))
+	default:
+		lines = append(lines, label.Render(fmt.Sprintf("    %-16s %6s  %s", "PLAYER", "RATING", "W/M")))
+		for i, p := range m.standings.top {
+			row := fmt.Sprintf("%2d. %-16s %6d  %d/%d", i+1, truncate(cleanName(p.Name), 16), p.Rating, p.Wins, p.Matches)
+			style := lipgloss.NewStyle()
+			if p.Fingerprint == m.standings.me.Fingerprint {
+				style = style.Foreground(colTitle).Bold(true)
+			}
+			lines = append(lines, style.Render(row))
+		}
+		me := m.standings.me
+		lines = append(lines, "", label.Render(
+			fmt.Sprintf("You: %d · %d wins in %d matches", me.Rating, me.Wins, me.Matches)))
+	}
+
+	return s.info.Width(standingsWidth + 2).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// historyView lists this player's recent matches.
+func (m *SharedMultiGame) historyView() string {
+	s := m.styles
+	label := s.sectionLbl.Width(standingsWidth)
+	lines := []string{s.title.Width(standingsWidth).Render("YOUR MATCHES"), ""}
+
+	switch {
+	case m.player.room.matches == nil:
+		lines = append(lines, label.Render("Ratings are off on this server."))
+	case m.standings.err != nil:
+		lines = append(lines, label.Render("Match history is unavailable."))
+	case len(m.standings.history) == 0:
+		lines = append(lines, label.Render("No rated matches yet."))
+	default:
+		lines = append(lines, label.Render(fmt.Sprintf("%-5s  %-14s %5s  %s", "PLACE", "ROOM", "SCORE", "RATING")))
+		for _, e := range m.standings.history {
+			change := lipgloss.NewStyle().Foreground(colDead).Render(fmt.Sprintf("%+d", e.RatingChange))
+			if e.RatingChange >= 0 {
+				change = lipgloss.NewStyle().Foreground(colTitle).Render(fmt.Sprintf("%+d", e.RatingChange))
+			}
+			place := fmt.Sprintf("%d/%d", e.Place, e.Players)
+			lines = append(lines, fmt.Sprintf("%-5s  %-14s %5d  %d %s", place, truncate(e.Room, 14), e.Score, e.Rating, change))
+		}
+	}
+
+	return s.info.Width(standingsWidth + 2).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
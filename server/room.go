@@ -2,50 +2,117 @@ package server
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/ssh"
+	"golang.org/x/crypto/bcrypt"
 
+	"github.com/HilthonTT/gosnake/internal/data"
 	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/ai"
 	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
 )
 
 const (
 	idleTimeout = 3 * time.Minute
+
+	// reconnectGrace is how long a dropped player's slot is held open
+	// mid-match. Their snake is kept alive by the autopilot meanwhile.
+	reconnectGrace = 30 * time.Second
+
+	// MaxSpectators caps a room's observers. It is separate from the player
+	// seats and the next-round queue.
+	MaxSpectators = 16
 )
 
-// Room is a single game session. At most MaxPlayers people play; additional
-// connections join as observers. The room owns the authoritative game state
-// and drives the tick loop via a time.Ticker.
+// RoomPhase is where a room is in its round cycle.
+type RoomPhase int
+
+const (
+	// PhaseWaiting is the time before and between rounds, when players
+	// ready up.
+	PhaseWaiting RoomPhase = iota
+	// PhaseCountdown is the 3-2-1 before a round's first tick.
+	PhaseCountdown
+	// PhasePlaying is a round in progress.
+	PhasePlaying
+)
+
+// countdownFrom is the number the pre-round countdown starts at.
+const countdownFrom = 3
+
+// Room is a single game session. At most rules.MaxPlayers people play;
+// sessions joining mid-round queue for the next one and any beyond that (or
+// that asked to spectate) join as observers, up to MaxSpectators. The room owns the authoritative
+// game state and drives the tick loop via a time.Ticker.
 type Room struct {
 	id       string
-	password string
+	password []byte // bcrypt hash, nil for open rooms
+	rules    atomic.Pointer[multi.Rules]
+
+	mu      sync.RWMutex
+	players map[string]*Player   // keyed by public-key string
+	away    map[string]*awaySlot // dropped players, same keys
+	seats   []*seat              // this (or the next) round's players, in player-index order
+	queue   []*seat              // late joiners waiting for the next round
+	owner   string               // key of the room owner, who can start early and kick
+	kicked  map[string]bool      // keys the owner removed; they may not rejoin
+	phase   RoomPhase
+
+	// Countdown state, only written by listen(), under mu since
+	// broadcastRoom may read it from other goroutines.
+	countdown     int
+	countdownNext time.Time
+
+	game *multi.Game     // nil until the first round starts
+	bots map[int]*ai.Bot // this round's bots by player index, only touched by listen()
+
+	matches *data.MatchRepository // nil when ratings are off
+	match   *tournamentMatch      // the tournament match played here, or nil
+
+	sync    chan tea.Msg  // inbound messages from player models
+	done    chan struct{} // closed by Close() to stop listen()
+	finish  chan string   // receives room id when the room should be deleted
+	closing sync.Once
+}
 
-	mu          sync.RWMutex
-	players     map[string]*Player // keyed by public-key string
-	playerNames []string           // ordered; used when restarting
-	nextIndex   int                // next player slot to assign (0-MaxPlayers)
-	started     bool               // true once the first game tick fires
+// seat is a place in a round, held by a public key or by a bot.
+type seat struct {
+	key         string
+	fingerprint string
+	name        string
+	ready       bool
 
-	game *multi.Game // nil until game starts
+	bot        bool // played by the server; always ready and never rated
+	difficulty ai.Difficulty
+}
 
-	sync   chan tea.Msg  // inbound messages from player models
-	done   chan struct{} // closed by Close() to stop listen()
-	finish chan string   // receives room id when the room should be deleted
+// awaySlot is a player slot held open for a session that dropped mid-match.
+type awaySlot struct {
+	index    int
+	name     string
+	deadline time.Time
 }
 
-func newRoom(id, password string, finish chan string) *Room {
+func newRoom(id string, passwordHash []byte, rules multi.Rules, matches *data.MatchRepository, match *tournamentMatch, finish chan string) *Room {
 	r := &Room{
 		id:       id,
-		password: password,
+		password: passwordHash,
+		matches:  matches,
+		match:    match,
 		players:  make(map[string]*Player),
+		away:     make(map[string]*awaySlot),
+		kicked:   make(map[string]bool),
 		sync:     make(chan tea.Msg, 128),
 		done:     make(chan struct{}, 1),
 		finish:   finish,
 	}
+	r.rules.Store(&rules)
 	go r.listen()
 	return r
 }
@@ -56,45 +123,143 @@ func (r *Room) String() string {
 
 // Close gracefully shuts down the room: notifies players and signals listen().
 func (r *Room) Close() {
-	log.Printf("closing room %s", r)
+	r.closeWith("Server is shutting down. Goodbye!")
+}
+
+// closeWith shuts down the room, telling every session why. Only the first
+// call has any effect, so an idle timeout racing an explicit close is safe.
+func (r *Room) closeWith(reason string) {
+	r.closing.Do(func() {
+		log.Printf("closing room %s", r)
+
+		// Copy the players first: closeOnce takes r.mu to forget them.
+		r.mu.RLock()
+		players := make([]*Player, 0, len(r.players))
+		for _, p := range r.players {
+			players = append(players, p)
+		}
+		r.mu.RUnlock()
+
+		for _, p := range players {
+			_, _ = p.WriteString("\n" + reason + "\n")
+			p.closeOnce()
+		}
 
+		// Signal listen() to exit without blocking if it already has.
+		select {
+		case r.done <- struct{}{}:
+		default:
+		}
+
+		r.finish <- r.id
+	})
+}
+
+// RoomInfo is a point-in-time summary of a room, used by the lobby listing.
+type RoomInfo struct {
+	ID         string
+	Players    int
+	MaxPlayers int
+	Observers  int
+	Started    bool
+	Locked     bool // password protected
+	Mode       string
+}
+
+// Info returns a snapshot of the room for listings.
+func (r *Room) Info() RoomInfo {
 	r.mu.RLock()
-	for _, p := range r.players {
-		_, _ = p.WriteString("\nServer is shutting down. Goodbye!\n")
-		p.closeOnce()
+	defer r.mu.RUnlock()
+
+	return RoomInfo{
+		ID:         r.id,
+		Players:    len(r.seats),
+		MaxPlayers: r.Rules().MaxPlayers,
+		Observers:  r.observersLocked(),
+		Started:    r.phase != PhaseWaiting,
+		Locked:     r.password != nil,
+		Mode:       r.Rules().Name(),
 	}
-	r.mu.RUnlock()
+}
 
-	// Signal listen() to exit. Use non-blocking send in case Close is called
-	// multiple times (e.g. idle timeout then explicit shutdown).
-	select {
-	case r.done <- struct{}{}:
-	default:
+// Rules returns the rules games in this room are played with. They only change
+// between rounds and are swapped atomically, so no lock is needed; views call
+// this while the room may be broadcasting to them under r.mu.
+func (r *Room) Rules() multi.Rules {
+	return *r.rules.Load()
+}
+
+// CheckPassword reports whether password unlocks the room. Open rooms only
+// take an empty password.
+func (r *Room) CheckPassword(password string) bool {
+	if r.password == nil {
+		return password == ""
 	}
+	return bcrypt.CompareHashAndPassword(r.password, []byte(password)) == nil
+}
 
-	r.finish <- r.id
+// JoinOptions controls how a session enters a room.
+type JoinOptions struct {
+	// Spectate joins as an observer even when a player slot is free.
+	Spectate bool
+	// Input is read for key presses instead of the session itself. It is set
+	// when the session has already run another program, such as the lobby.
+	Input io.Reader
 }
 
 // AddPlayer assigns a session to a player or observer slot and wires it up.
-func (r *Room) AddPlayer(s ssh.Session) (*Player, error) {
+func (r *Room) AddPlayer(s ssh.Session, opts JoinOptions) (*Player, error) {
 	k := s.PublicKey()
 	if k == nil {
 		return nil, fmt.Errorf("no public key — re-run with: ssh -i <key> ...")
 	}
 	pub := PublicKey{key: k}
 
+	// Only the two entrants may play a tournament match; everyone else
+	// watches.
+	entrant := r.match == nil || r.match.mayPlay(s.User(), pub.Fingerprint())
+
 	r.mu.Lock()
 
-	if _, ok := r.players[pub.String()]; ok {
+	if r.kicked[pub.String()] {
 		r.mu.Unlock()
-		return nil, fmt.Errorf("you are already connected to this room")
+		return nil, fmt.Errorf("you were removed from this room by its owner")
 	}
 
 	idx := -1
-	if !r.started && r.nextIndex < multi.MaxPlayers {
-		idx = r.nextIndex
-		r.nextIndex++
-		r.playerNames = append(r.playerNames, s.User())
+	queued := false
+	slot, rejoin := r.away[pub.String()]
+	stale := r.players[pub.String()]
+	switch {
+	case stale != nil:
+		// The same key connected again, usually because its old connection
+		// died without the server noticing yet. The new session takes over
+		// the old one's seat, snake or place in the queue.
+		idx, queued = stale.playerIndex, stale.queued
+		stale.playerIndex, stale.queued, stale.superseded = -1, false, true
+		delete(r.away, pub.String())
+		rejoin = true
+	case opts.Spectate || !entrant:
+	case rejoin:
+		// Back within the grace period: take over the held snake.
+		delete(r.away, pub.String())
+		idx = slot.index
+	case r.phase == PhaseWaiting && (len(r.seats) < r.Rules().MaxPlayers || r.yieldBotLocked()):
+		// Bots only fill seats nobody wants; a person joining a full room
+		// takes the last bot's.
+		r.seats = append(r.seats, &seat{key: pub.String(), fingerprint: pub.Fingerprint(), name: s.User()})
+		idx = len(r.seats) - 1
+	case r.phase != PhaseWaiting && len(r.queue) < r.Rules().MaxPlayers:
+		r.queue = append(r.queue, &seat{key: pub.String(), fingerprint: pub.Fingerprint(), name: s.User()})
+		queued = true
+	}
+	if stale == nil && idx < 0 && !queued && r.observersLocked() >= MaxSpectators {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("room %q already has %d spectators", r.id, MaxSpectators)
+	}
+
+	if r.owner == "" && (idx >= 0 || queued) {
+		r.owner = pub.String()
 	}
 
 	p := &Player{
@@ -102,6 +267,8 @@ func (r *Room) AddPlayer(s ssh.Session) (*Player, error) {
 		session:     s,
 		key:         pub,
 		playerIndex: idx,
+		queued:      queued,
+		chat:        newChatLimiter(),
 	}
 
 	p.game = newSharedMultiGame(p, r.sync)
@@ -112,32 +279,151 @@ func (r *Room) AddPlayer(s ssh.Session) (*Player, error) {
 	p.game.height = ptyInfo.Window.Height
 	p.wchan = wchan
 
+	var in io.Reader = s
+	if opts.Input != nil {
+		in = opts.Input
+	}
+
 	prog := tea.NewProgram(
 		p.game,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
-		tea.WithInput(s),
+		tea.WithInput(in),
 		tea.WithOutput(s),
 	)
 	p.program = prog
 
 	r.players[pub.String()] = p
 
-	joinMsg := NoteMsg(fmt.Sprintf("%s joined as %s", s.User(), p.roleString()))
+	joinMsg := NoteMsg(fmt.Sprintf("%s joined as %s", s.User(), p.roleLocked()))
+	if rejoin && idx >= 0 {
+		joinMsg = NoteMsg(fmt.Sprintf("%s reconnected as %s", s.User(), p.roleLocked()))
+	}
 	r.mu.Unlock()
 
+	if stale != nil {
+		_, _ = stale.WriteString("\nYou connected to this room again from another session.\n")
+		stale.closeOnce()
+	}
+
 	// Route through the sync channel (buffered, 128) instead of calling
 	// broadcast directly. At this point p.program.Run() hasn't been called
 	// yet, so program.Send() would block. The listen() goroutine will pick
 	// this up and fan it out once all programs are actually running.
-	select {
-	case r.sync <- joinMsg:
-	default:
-	}
+	r.post(joinMsg, roomChangedMsg{})
 
 	return p, nil
 }
 
+// seatIndexLocked returns the index of key's seat, or -1. Caller must hold r.mu.
+func (r *Room) seatIndexLocked(key string) int {
+	for i, st := range r.seats {
+		if st.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// reindexLocked brings every player's index in line with the seat order and
+// queue membership. Only valid between rounds. Caller must hold r.mu.Lock.
+func (r *Room) reindexLocked() {
+	for _, p := range r.players {
+		p.playerIndex = r.seatIndexLocked(p.key.String())
+		p.queued = false
+		for _, q := range r.queue {
+			if q.key == p.key.String() {
+				p.queued = true
+			}
+		}
+	}
+}
+
+// observersLocked counts sessions that neither play nor wait for the next
+// round. Caller must hold r.mu.
+func (r *Room) observersLocked() int {
+	n := 0
+	for _, p := range r.players {
+		if p.playerIndex < 0 && !p.queued {
+			n++
+		}
+	}
+	return n
+}
+
+// pickOwnerLocked hands ownership to the first seated (or else queued)
+// player if the current owner is gone. Caller must hold r.mu.Lock.
+func (r *Room) pickOwnerLocked() {
+	if _, ok := r.players[r.owner]; ok {
+		return
+	}
+	if _, ok := r.away[r.owner]; ok {
+		return
+	}
+
+	r.owner = ""
+	for _, list := range [][]*seat{r.seats, r.queue} {
+		for _, st := range list {
+			if _, ok := r.players[st.key]; ok {
+				r.owner = st.key
+				return
+			}
+		}
+	}
+}
+
+// holdSlot keeps a dropped player's slot open for reconnectGrace if a match
+// is in progress. It reports whether the slot is being held.
+func (r *Room) holdSlot(p *Player) bool {
+	r.mu.Lock()
+	key := p.key.String()
+	if r.phase == PhaseWaiting || p.playerIndex < 0 || r.kicked[key] || r.seatIndexLocked(key) < 0 {
+		r.mu.Unlock()
+		return false
+	}
+	r.away[key] = &awaySlot{
+		index:    p.playerIndex,
+		name:     p.session.User(),
+		deadline: time.Now().Add(reconnectGrace),
+	}
+	r.mu.Unlock()
+
+	note := NoteMsg(fmt.Sprintf("%s disconnected — autopilot for %s while they reconnect", p.session.User(), reconnectGrace))
+	r.post(note, roomChangedMsg{})
+	return true
+}
+
+// steerAway drives the snakes of dropped players and forfeits the ones whose
+// grace period has run out. Must only be called from listen().
+func (r *Room) steerAway() {
+	now := time.Now()
+
+	r.mu.Lock()
+	var steer, expired []int
+	for k, slot := range r.away {
+		if now.After(slot.deadline) {
+			delete(r.away, k)
+			expired = append(expired, slot.index)
+			continue
+		}
+		steer = append(steer, slot.index)
+	}
+	if len(expired) > 0 {
+		r.pickOwnerLocked()
+	}
+	r.mu.Unlock()
+
+	for _, idx := range expired {
+		r.game.Eliminate(idx, snake.CauseDisconnect)
+	}
+	if len(expired) > 0 {
+		r.broadcastRoom()
+	}
+	for _, idx := range steer {
+		r.game.Autopilot(idx)
+	}
+}
+
 // broadcastLocked sends msg to all players. Caller MUST hold at least r.mu.RLock.
 func (r *Room) broadcastLocked(msg tea.Msg) {
 	for _, p := range r.players {
@@ -152,9 +438,50 @@ func (r *Room) broadcast(msg tea.Msg) {
 	r.mu.RUnlock()
 }
 
+// say cleans a chat message and broadcasts it, dropping it if the sender is
+// over their rate limit.
+func (r *Room) say(m SayMsg) {
+	r.mu.RLock()
+	p, ok := r.players[m.Key]
+	idx := -1
+	if ok {
+		idx = p.playerIndex
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	if !p.chat.Allow() {
+		p.Send(NoteMsg("You're sending messages too quickly"))
+		return
+	}
+	text := cleanChat(m.Text)
+	if text == "" {
+		return
+	}
+
+	color := colMuted
+	if idx >= 0 {
+		color = snakeColor(idx, r.Rules().Teams).head
+	}
+	r.broadcast(ChatMsg{Name: cleanName(p.session.User()), Color: color, Text: text})
+}
+
 // sendNote broadcasts a plain-text note to all players.
 func (r *Room) sendNote(s string) { r.broadcast(NoteMsg(s)) }
 
+// post hands msgs to listen() from another goroutine, dropping any the room
+// has no room for rather than blocking.
+func (r *Room) post(msgs ...tea.Msg) {
+	for _, msg := range msgs {
+		select {
+		case r.sync <- msg:
+		default:
+		}
+	}
+}
+
 // broadcastState deep-copies the current game state and sends it to everyone.
 // Must NOT be called while holding r.mu.
 func (r *Room) broadcastState(died []int) {
@@ -162,13 +489,16 @@ func (r *Room) broadcastState(died []int) {
 		return
 	}
 
-	// Deep-copy the matrix so every player gets its own slice.
-	src := r.game.Matrix()
-	matrix := make(snake.Matrix, len(src))
-	for i, row := range src {
-		matrix[i] = make([]byte, len(row))
-		copy(matrix[i], row)
+	// Deep-copy the matrix layers so every player gets its own slices.
+	matrix := copyMatrix(r.game.Matrix())
+	owners := copyMatrix(r.game.Owners())
+
+	r.mu.RLock()
+	away := make(map[int]bool, len(r.away))
+	for _, slot := range r.away {
+		away[slot.index] = true
 	}
+	r.mu.RUnlock()
 
 	// Snapshot players (value copy — no shared pointers).
 	rawPlayers := r.game.Players()
@@ -179,28 +509,44 @@ func (r *Room) broadcastState(died []int) {
 			Name:   p.Name,
 			Score:  p.Score,
 			Alive:  p.Alive,
+			Away:   away[p.Index],
 			Length: len(p.Points),
+			Team:   p.Team,
 		}
 	}
 
 	msg := GameStateMsg{
-		Matrix:  matrix,
-		Players: snapshots,
-		Level:   r.game.Level(),
-		Over:    r.game.IsOver(),
-		Winner:  r.game.Winner(),
-		Died:    died,
+		Matrix:    matrix,
+		Owners:    owners,
+		Players:   snapshots,
+		Level:     r.game.Level(),
+		Remaining: r.game.Remaining(),
+		Over:      r.game.IsOver(),
+		Winner:    r.game.Winner(),
+		Died:      died,
+
+		TeamScores: r.game.TeamScores(),
+		WinnerTeam: r.game.WinningTeam(),
 	}
 	r.broadcast(msg)
 }
 
+func copyMatrix(src snake.Matrix) snake.Matrix {
+	dst := make(snake.Matrix, len(src))
+	for i, row := range src {
+		dst[i] = make([]byte, len(row))
+		copy(dst[i], row)
+	}
+	return dst
+}
+
 // listen is the room's single-threaded event loop.
 // It owns the game object exclusively — no other goroutine touches r.game.
 func (r *Room) listen() {
 	ticker := time.NewTicker(snake.GetTickInterval(1))
 	defer ticker.Stop()
 
-	// Single timer so the idle timeout does not silently reset every loop iteration.
+	// Single timer, reset only by player activity and rounds in progress.
 	idle := time.NewTimer(idleTimeout)
 	defer idle.Stop()
 
@@ -222,68 +568,337 @@ func (r *Room) listen() {
 			if !ok {
 				return
 			}
+			idle.Reset(idleTimeout)
 			switch m := msg.(type) {
 
 			case NoteMsg:
 				r.broadcast(m)
 
+			case SayMsg:
+				r.say(m)
+
 			case DirectionMsg:
-				if r.game != nil {
+				if r.game != nil && r.currentPhase() == PhasePlaying {
 					r.game.ChangeDirection(m.PlayerIndex, snake.Direction(m.Direction))
 				}
 
-			case RestartMsg:
-				// Any player can trigger an immediate restart.
-				r.doRestart()
+			case ReadyMsg:
+				r.toggleReady(m.Key)
+
+			case StartMsg:
+				r.ownerStart(m.Key)
+
+			case KickMsg:
+				r.kick(m.Key, m.Target)
+
+			case AddBotMsg:
+				r.addBot(m.Key, m.Difficulty)
+
+			case roomChangedMsg:
+				r.broadcastRoom()
+
+			case leaveMsg:
+				r.leave(m.key)
+
+			case adminKickMsg:
+				m.reply <- r.adminKick(m.target)
+
+			case setRulesMsg:
+				m.reply <- r.setRules(m.rules)
 			}
 
 		// Game tick
 		case <-ticker.C:
-			// Start the game once ≥2 players are connected.
-			if r.game == nil {
-				r.mu.RLock()
-				nPlayers := r.nextIndex
-				names := make([]string, len(r.playerNames))
-				copy(names, r.playerNames)
-				r.mu.RUnlock()
-
-				if nPlayers >= 2 {
-					r.mu.Lock()
-					r.started = true
-					r.mu.Unlock()
-
-					r.game = multi.NewGame(names)
-					r.sendNote("Game started! Good luck!")
-					r.broadcastState(nil)
+			switch r.currentPhase() {
+			case PhaseCountdown:
+				r.stepCountdown(ticker)
+
+			case PhasePlaying:
+				idle.Reset(idleTimeout)
+				r.steerAway()
+				r.steerBots()
+				died := r.game.Tick()
+
+				// Adjust tick speed to the new level after the move.
+				ticker.Reset(snake.GetTickInterval(r.game.Level()))
+
+				r.broadcastState(died)
+				if r.game.IsOver() {
+					r.endRound()
 				}
-				continue
 			}
+		}
+	}
+}
 
-			// Don't tick a finished game — wait for a restart vote.
-			if r.game.IsOver() {
-				continue
-			}
+func (r *Room) currentPhase() RoomPhase {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.phase
+}
 
-			died := r.game.Tick()
+// toggleReady flips a seated player's ready flag between rounds and starts
+// the countdown once everyone is ready. Un-readying cancels a countdown.
+func (r *Room) toggleReady(key string) {
+	r.mu.Lock()
+	i := r.seatIndexLocked(key)
+	if i < 0 || r.phase == PhasePlaying {
+		r.mu.Unlock()
+		return
+	}
+	st := r.seats[i]
+	st.ready = !st.ready
+	cancelled := !st.ready && r.phase == PhaseCountdown
+	if cancelled {
+		r.phase = PhaseWaiting
+	}
+	r.mu.Unlock()
 
-			// Adjust tick speed to the new level after the move.
-			ticker.Reset(snake.GetTickInterval(r.game.Level()))
+	if cancelled {
+		r.sendNote(fmt.Sprintf("%s is not ready — countdown cancelled", st.name))
+	}
+	r.broadcastRoom()
+	r.maybeStart()
+}
 
-			r.broadcastState(died)
-		}
+// maybeStart begins the countdown when at least two players, one of them a
+// person, are seated and all of them are ready.
+func (r *Room) maybeStart() {
+	r.mu.RLock()
+	ok := r.phase == PhaseWaiting && len(r.seats) >= 2 && r.humansLocked() > 0
+	for _, st := range r.seats {
+		ok = ok && st.ready
+	}
+	r.mu.RUnlock()
+
+	if ok {
+		r.startCountdown()
 	}
 }
 
-// doRestart resets the game and notifies all players.
-func (r *Room) doRestart() {
+// ownerStart lets the owner start the countdown without waiting for
+// everyone to ready up.
+func (r *Room) ownerStart(key string) {
 	r.mu.RLock()
-	names := make([]string, len(r.playerNames))
-	copy(names, r.playerNames)
+	ok := key == r.owner && r.phase == PhaseWaiting && len(r.seats) >= 2
 	r.mu.RUnlock()
 
-	r.game = multi.NewGame(names)
+	if ok {
+		r.sendNote("The owner started the round")
+		r.startCountdown()
+	}
+}
+
+func (r *Room) startCountdown() {
+	r.mu.Lock()
+	r.phase = PhaseCountdown
+	r.countdown = countdownFrom
+	r.countdownNext = time.Now()
+	r.mu.Unlock()
+}
+
+// stepCountdown broadcasts the next countdown number once a second and starts
+// the round after the last one.
+func (r *Room) stepCountdown(ticker *time.Ticker) {
+	now := time.Now()
+	if now.Before(r.countdownNext) {
+		return
+	}
+	if r.countdown == 0 {
+		r.beginRound(ticker)
+		return
+	}
+
+	r.broadcastRoom()
+	r.mu.Lock()
+	r.countdown--
+	r.countdownNext = now.Add(time.Second)
+	r.mu.Unlock()
+}
+
+// beginRound starts a new game with the seated players.
+func (r *Room) beginRound(ticker *time.Ticker) {
+	r.mu.Lock()
+	names := make([]string, len(r.seats))
+	for i, st := range r.seats {
+		names[i] = st.name
+		st.ready = st.bot
+	}
+	rules := r.Rules()
+	r.phase = PhasePlaying
+	r.mu.Unlock()
+
+	r.game = multi.NewGame(names, rules)
+	r.startBots()
+	r.watchGame()
+	ticker.Reset(snake.GetTickInterval(r.game.Level()))
 
 	// Tell every client to clear their local state before the first tick arrives.
 	r.broadcast(RestartMsg{})
-	r.sendNote("Game restarted!")
+	r.broadcastRoom()
+	r.sendNote("Go! Good luck!")
+	r.broadcastState(nil)
+}
+
+// endRound returns the room to the waiting phase: players who left give up
+// their seats and queued players take the free ones.
+func (r *Room) endRound() {
+	if r.matches != nil {
+		if m, ok := r.matchResult(); ok {
+			go r.recordMatch(m)
+		}
+	}
+	if r.match != nil {
+		r.reportMatch()
+	}
+
+	r.mu.Lock()
+	r.phase = PhaseWaiting
+	clear(r.away)
+
+	seats := r.seats[:0]
+	for _, st := range r.seats {
+		if _, ok := r.players[st.key]; ok || st.bot {
+			seats = append(seats, st)
+		}
+	}
+	r.seats = seats
+
+	var promoted []string
+	queue := r.queue[:0]
+	for _, q := range r.queue {
+		if _, ok := r.players[q.key]; !ok {
+			continue
+		}
+		if len(r.seats) < r.Rules().MaxPlayers || r.yieldBotLocked() {
+			r.seats = append(r.seats, q)
+			promoted = append(promoted, q.name)
+			continue
+		}
+		queue = append(queue, q)
+	}
+	r.queue = queue
+
+	r.reindexLocked()
+	r.pickOwnerLocked()
+	r.mu.Unlock()
+
+	for _, name := range promoted {
+		r.sendNote(fmt.Sprintf("%s takes a seat for the next round", name))
+	}
+	r.broadcastRoom()
+}
+
+// leave forgets a session that has left the room. Between rounds its seat is
+// freed straight away; mid-round its snake is eliminated and the seat freed
+// when the round ends.
+func (r *Room) leave(key string) {
+	r.mu.Lock()
+	queue := r.queue[:0]
+	for _, q := range r.queue {
+		if q.key != key {
+			queue = append(queue, q)
+		}
+	}
+	r.queue = queue
+
+	idx := r.seatIndexLocked(key)
+	phase := r.phase
+	if idx >= 0 && phase != PhasePlaying {
+		r.seats = append(r.seats[:idx], r.seats[idx+1:]...)
+		if phase == PhaseCountdown && (len(r.seats) < 2 || r.humansLocked() == 0) {
+			r.phase = PhaseWaiting
+		}
+		r.reindexLocked()
+	}
+	r.pickOwnerLocked()
+	r.mu.Unlock()
+
+	if idx >= 0 && phase == PhasePlaying {
+		r.game.Eliminate(idx, snake.CauseDisconnect)
+	}
+	r.broadcastRoom()
+	r.maybeStart()
+}
+
+// kick removes target, a player or a bot, from the room if key is the
+// owner's. Bots can only be removed between rounds. Nobody can be kicked
+// from a tournament match.
+func (r *Room) kick(key, target string) {
+	if r.match != nil {
+		return
+	}
+
+	r.mu.Lock()
+	if key == r.owner && r.phase != PhasePlaying {
+		if st := r.removeBotLocked(target); st != nil {
+			if r.phase == PhaseCountdown && len(r.seats) < 2 {
+				r.phase = PhaseWaiting
+			}
+			r.mu.Unlock()
+
+			r.sendNote(fmt.Sprintf("%s was removed by the owner", st.name))
+			r.broadcastRoom()
+			return
+		}
+	}
+	p, ok := r.players[target]
+	if key != r.owner || target == r.owner || !ok {
+		r.mu.Unlock()
+		return
+	}
+	r.kicked[target] = true
+	delete(r.away, target)
+	r.mu.Unlock()
+
+	_, _ = p.WriteString("\nYou were removed from the room by its owner.\n")
+	p.closeOnce()
+	r.sendNote(fmt.Sprintf("%s was removed by the owner", p.session.User()))
+	r.leave(target)
+}
+
+// broadcastRoom sends everyone the seat list, queue and phase.
+func (r *Room) broadcastRoom() {
+	r.mu.RLock()
+	msg := RoomStateMsg{
+		Phase:     r.phase,
+		Countdown: r.countdown,
+	}
+	for i, st := range r.seats {
+		_, away := r.away[st.key]
+		msg.Seats = append(msg.Seats, SeatInfo{
+			Index: i,
+			Name:  st.name,
+			Key:   st.key,
+			Ready: st.ready,
+			Owner: st.key == r.owner,
+			Away:  away,
+			Bot:   st.bot,
+			Level: st.difficulty,
+		})
+	}
+	for _, q := range r.queue {
+		msg.Queue = append(msg.Queue, SeatInfo{
+			Index: -1,
+			Name:  q.name,
+			Key:   q.key,
+			Owner: q.key == r.owner,
+		})
+	}
+	msg.Observers = r.observersLocked()
+	r.mu.RUnlock()
+
+	r.broadcast(msg)
+}
+
+// watchGame forwards the current game's notable events to every player as
+// notes. Handlers run inside r.game.Tick() on the listen goroutine, which never
+// holds r.mu at that point, so broadcasting from here is safe.
+func (r *Room) watchGame() {
+	r.game.Events().Subscribe(func(e snake.Event) {
+		switch e.(type) {
+		case snake.SnakeDied, snake.LevelUp:
+			r.sendNote(e.String())
+		}
+	})
 }
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HilthonTT/gosnake/pkg/snake"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+)
+
+// ruleChoice is one selectable value of a ruleOption.
+type ruleChoice struct {
+	label string
+	apply func(*multi.Rules)
+}
+
+// ruleOption is a row of the lobby's new room form that cycles through a
+// fixed set of values with ←/→.
+type ruleOption struct {
+	label   string
+	choices []ruleChoice
+	index   int
+}
+
+func (o *ruleOption) cycle(delta int) {
+	o.index = (o.index + delta + len(o.choices)) % len(o.choices)
+}
+
+func (o *ruleOption) value() string {
+	return o.choices[o.index].label
+}
+
+// newRuleOptions returns the rule rows of the new room form, each set to the
+// default rules' value.
+func newRuleOptions() []ruleOption {
+	boards := [][2]int{{snake.DefaultCols, snake.DefaultRows}, {30, 20}, {38, 30}, {multi.MaxCols, multi.MaxRows}}
+	board := ruleOption{label: "Board"}
+	for _, b := range boards {
+		board.choices = append(board.choices, ruleChoice{
+			label: fmt.Sprintf("%d×%d", b[0], b[1]),
+			apply: func(r *multi.Rules) { r.Cols, r.Rows = b[0], b[1] },
+		})
+	}
+
+	food := ruleOption{label: "Food", choices: []ruleChoice{
+		{label: "1 per player", apply: func(r *multi.Rules) { r.Food = 0 }},
+	}}
+	for _, n := range []int{1, 2, 3, 5, 8} {
+		food.choices = append(food.choices, ruleChoice{
+			label: fmt.Sprint(n),
+			apply: func(r *multi.Rules) { r.Food = n },
+		})
+	}
+
+	speed := ruleOption{label: "Speed"}
+	for level := 1; level <= multi.MaxLevel; level++ {
+		speed.choices = append(speed.choices, ruleChoice{
+			label: fmt.Sprintf("level %d", level),
+			apply: func(r *multi.Rules) { r.StartLevel = level },
+		})
+	}
+
+	seats := ruleOption{label: "Seats"}
+	for n := multi.MaxPlayers; n >= 2; n-- {
+		seats.choices = append(seats.choices, ruleChoice{
+			label: fmt.Sprint(n),
+			apply: func(r *multi.Rules) { r.MaxPlayers = n },
+		})
+	}
+
+	win := ruleOption{label: "Win", choices: []ruleChoice{
+		{label: "last snake alive", apply: func(r *multi.Rules) { r.Win = multi.WinLastAlive }},
+	}}
+	for _, target := range []int{100, 200, 500} {
+		win.choices = append(win.choices, ruleChoice{
+			label: fmt.Sprintf("first to %d points", target),
+			apply: func(r *multi.Rules) { r.Win, r.TargetScore = multi.WinFirstTo, target },
+		})
+	}
+	for _, limit := range []time.Duration{2 * time.Minute, 3 * time.Minute, 5 * time.Minute} {
+		win.choices = append(win.choices, ruleChoice{
+			label: fmt.Sprintf("best score in %s", multi.ShortDuration(limit)),
+			apply: func(r *multi.Rules) { r.Win, r.TimeLimit = multi.WinTimeLimit, limit },
+		})
+	}
+
+	return []ruleOption{
+		board,
+		{label: "Walls", choices: []ruleChoice{
+			{label: "solid", apply: func(r *multi.Rules) { r.Wrap = false }},
+			{label: "wrap around", apply: func(r *multi.Rules) { r.Wrap = true }},
+		}},
+		{label: "Bombs", choices: []ruleChoice{
+			{label: "off", apply: func(r *multi.Rules) { r.Bombs = false }},
+			{label: "on", apply: func(r *multi.Rules) { r.Bombs = true }},
+		}},
+		food,
+		speed,
+		seats,
+		{label: "Teams", choices: []ruleChoice{
+			{label: "off", apply: func(r *multi.Rules) { r.Teams, r.FriendlyFire = false, false }},
+			{label: fmt.Sprintf("%d teams", multi.NumTeams), apply: func(r *multi.Rules) { r.Teams, r.FriendlyFire = true, false }},
+			{label: fmt.Sprintf("%d teams, friendly fire", multi.NumTeams), apply: func(r *multi.Rules) { r.Teams, r.FriendlyFire = true, true }},
+		}},
+		win,
+	}
+}
+
+// rulesFrom builds the rules selected in opts.
+func rulesFrom(opts []ruleOption) multi.Rules {
+	rules := multi.DefaultRules()
+	for _, o := range opts {
+		o.choices[o.index].apply(&rules)
+	}
+	return rules
+}
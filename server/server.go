@@ -2,12 +2,18 @@ package server
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 	"sync"
 
 	gossh "golang.org/x/crypto/ssh"
 
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/activeterm"
@@ -27,31 +33,59 @@ func (pk PublicKey) String() string {
 	return fmt.Sprintf("%s", gossh.MarshalAuthorizedKey(pk.key))
 }
 
+// Fingerprint returns the key's SHA256 fingerprint, which identifies a player
+// in the ratings database.
+func (pk PublicKey) Fingerprint() string {
+	return gossh.FingerprintSHA256(pk.key)
+}
+
+// ErrRoomExists is returned by CreateRoom when the id is already taken.
+var ErrRoomExists = errors.New("a room with that id already exists")
+
 // Server manages multiplayer snake rooms over SSH.
 type Server struct {
-	host  string
-	port  int
-	srv   *ssh.Server
-	rooms map[string]*Room
-	mu    sync.Mutex
+	host       string
+	port       int
+	srv        *ssh.Server
+	rooms      map[string]*Room
+	matches    *data.MatchRepository // nil when results are not persisted
+	matchmaker *matchmaker
+	access     *Access // nil when the server is open to everyone
+	ipLimiter  *ipRateLimiter
+
+	tournaments *tournaments
+	mu          sync.Mutex
 }
 
 // NewServer creates and configures the SSH server.
 // keyPath is the path used to persist the server's host key across restarts.
-func NewServer(keyPath, host string, port int) (*Server, error) {
+// Finished matches, player ratings and tournaments are stored in db; a nil db
+// turns ratings off and keeps tournaments in memory only. access decides who
+// may connect; nil lets everyone in.
+func NewServer(keyPath, host string, port int, db *sql.DB, access *Access) (*Server, error) {
 	s := &Server{
-		host:  host,
-		port:  port,
-		rooms: make(map[string]*Room),
+		host:   host,
+		port:   port,
+		rooms:  make(map[string]*Room),
+		access: access,
+	}
+	var tournamentRepo *data.TournamentRepository
+	if db != nil {
+		if err := data.EnsureServerTables(db); err != nil {
+			return nil, fmt.Errorf("failed to create server tables: %w", err)
+		}
+		s.matches = data.NewMatchRepository(db)
+		tournamentRepo = data.NewTournamentRepository(db)
 	}
+	s.matchmaker = newMatchmaker(s)
+	s.tournaments = newTournaments(s, tournamentRepo)
 
 	globalRL := ratelimiter.NewRateLimiter(globalRateLimit, globalBurst, globalMaxSessions)
-	ipRL := newIPRateLimiter()
+	s.ipLimiter = newIPRateLimiter()
 
 	ws, err := wish.NewServer(
-		// Accept any password and any public key — room passwords handle auth.
-		ssh.PasswordAuth(func(_ ssh.Context, _ string) bool { return true }),
-		ssh.PublicKeyAuth(func(_ ssh.Context, _ ssh.PublicKey) bool { return true }),
+		ssh.PasswordAuth(access.passwordAuth),
+		ssh.PublicKeyAuth(access.publicKeyAuth),
 		wish.WithHostKeyPath(keyPath),
 		wish.WithAddress(fmt.Sprintf("%s:%d", host, port)),
 		wish.WithMiddleware(
@@ -59,7 +93,7 @@ func NewServer(keyPath, host string, port int) (*Server, error) {
 			activeterm.Middleware(),
 			logging.Middleware(),
 			recover.Middleware(),
-			perIPMiddleware(ipRL),
+			perIPMiddleware(s.ipLimiter),
 			ratelimiter.Middleware(globalRL),
 		),
 	)
@@ -80,6 +114,9 @@ func (s *Server) Start() error {
 
 // Shutdown gracefully closes all rooms and the underlying SSH server.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.matchmaker.stop()
+	s.tournaments.stop()
+
 	s.mu.Lock()
 	for _, room := range s.rooms {
 		room.Close()
@@ -95,9 +132,64 @@ func (s *Server) FindRoom(id string) *Room {
 	return s.rooms[id]
 }
 
-// NewRoom creates, registers, and returns a new room.
+// ListRooms returns a snapshot of every open room, ordered by id.
+func (s *Server) ListRooms() []RoomInfo {
+	rooms := s.roomList()
+	infos := make([]RoomInfo, len(rooms))
+	for i, room := range rooms {
+		infos[i] = room.Info()
+	}
+	return infos
+}
+
+// roomList returns every open room, ordered by id.
+func (s *Server) roomList() []*Room {
+	s.mu.Lock()
+	rooms := make([]*Room, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(rooms, func(a, b *Room) int { return strings.Compare(a.id, b.id) })
+	return rooms
+}
+
+// CreateRoom creates, registers, and returns a new room. It fails with
+// ErrRoomExists instead of replacing an existing room, and with
+// errRoomReserved for the ids of tournament match rooms.
+func (s *Server) CreateRoom(id, password string, rules multi.Rules) (*Room, error) {
+	if s.tournaments.reserved(id) {
+		return nil, errRoomReserved
+	}
+	return s.createRoom(id, password, rules, nil)
+}
+
+// createMatchRoom opens the room a tournament match is played in.
+func (s *Server) createMatchRoom(id string, match *tournamentMatch) (*Room, error) {
+	return s.createRoom(id, "", tournamentRules(), match)
+}
+
+func (s *Server) createRoom(id, password string, rules multi.Rules, match *tournamentMatch) (*Room, error) {
+	// bcrypt is slow on purpose; hash before taking s.mu so room lookups
+	// don't wait on it.
+	hash := hashPassword(password)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rooms[id]; ok {
+		return nil, ErrRoomExists
+	}
+	room := s.newRoom(id, hash, rules, match)
+	s.rooms[id] = room
+	return room, nil
+}
+
+// newRoom builds a room without registering it. passwordHash is the room
+// password as hashed by hashPassword, or nil for an open room.
 // A goroutine watches the finish channel so the room self-removes on close
-func (s *Server) NewRoom(id, password string) *Room {
+func (s *Server) newRoom(id string, passwordHash []byte, rules multi.Rules, match *tournamentMatch) *Room {
 	finish := make(chan string, 1)
 	go func() {
 		rid := <-finish
@@ -108,10 +200,5 @@ func (s *Server) NewRoom(id, password string) *Room {
 		close(finish)
 	}()
 
-	room := newRoom(id, password, finish)
-	s.mu.Lock()
-	s.rooms[id] = room
-	s.mu.Unlock()
-
-	return room
+	return newRoom(id, passwordHash, rules, s.matches, match, finish)
 }
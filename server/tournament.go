@@ -0,0 +1,827 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+	"github.com/HilthonTT/gosnake/pkg/snake/modes/multi"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+)
+
+const (
+	// tournamentSync is how often the rooms of playable matches are checked
+	// and reopened if they have closed.
+	tournamentSync = 10 * time.Second
+	// maxTournamentIDLen leaves room in match room ids for the match number.
+	maxTournamentIDLen = maxRoomIDLen - 4
+	// maxTournamentPlayers bounds the field of one tournament.
+	maxTournamentPlayers = 64
+	// bracketPanelRows is how many lines the bracket panel shows before it starts
+	// leaving out decided rounds.
+	bracketPanelRows = 24
+)
+
+var (
+	errNoTournament       = errors.New("no tournament with that id")
+	errTournamentExists   = errors.New("a tournament with that id already exists")
+	errNotOrganiser       = errors.New("only the tournament's organiser can do that")
+	errNotAdmin           = errors.New("only server admins can create tournaments")
+	errTournamentStarted  = errors.New("the tournament has already started")
+	errTournamentNotReady = errors.New("a tournament needs at least two players")
+	errRoomReserved       = errors.New("that room id belongs to a tournament match")
+)
+
+// tournamentRules are the rules every tournament match is played under.
+func tournamentRules() multi.Rules {
+	rules := multi.DefaultRules()
+	rules.MaxPlayers = 2
+	return rules
+}
+
+// matchRoomID is the id of the room a tournament match is played in.
+func matchRoomID(tournament string, match int) string {
+	return fmt.Sprintf("%s-%d", tournament, match)
+}
+
+// tournaments hosts the server's bracket tournaments. It opens a room for
+// every match as soon as both players are known, moves winners on as rooms
+// report results and keeps the state in the database so a restart picks up
+// where it left off.
+type tournaments struct {
+	srv  *Server
+	repo *data.TournamentRepository // nil when tournaments are not persisted
+
+	mu  sync.Mutex
+	all map[string]*data.Tournament
+
+	// saveMu orders writes to the database, which happen outside mu.
+	saveMu sync.Mutex
+
+	done chan struct{}
+}
+
+func newTournaments(srv *Server, repo *data.TournamentRepository) *tournaments {
+	ts := &tournaments{
+		srv:  srv,
+		repo: repo,
+		all:  make(map[string]*data.Tournament),
+		done: make(chan struct{}),
+	}
+
+	if repo != nil {
+		unfinished, err := repo.Unfinished()
+		if err != nil {
+			log.Printf("failed to load tournaments: %v", err)
+		}
+		for _, t := range unfinished {
+			ts.all[t.ID] = &t
+		}
+		if len(unfinished) > 0 {
+			log.Printf("resumed %d tournament(s)", len(unfinished))
+		}
+	}
+
+	go ts.run()
+	return ts
+}
+
+func (ts *tournaments) run() {
+	ts.openRooms()
+
+	ticker := time.NewTicker(tournamentSync)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ts.done:
+			return
+		case <-ticker.C:
+			ts.openRooms()
+		}
+	}
+}
+
+func (ts *tournaments) stop() {
+	close(ts.done)
+}
+
+// save persists the current state of tournament id, logging failures; the
+// in-memory state stays authoritative. It must be called without ts.mu held,
+// so a slow disk never stalls room creation, which checks reserved ids.
+func (ts *tournaments) save(id string) {
+	if ts.repo == nil {
+		return
+	}
+
+	// Saves are serialised and each writes the latest state, so a slow save
+	// can't overwrite a newer one.
+	ts.saveMu.Lock()
+	defer ts.saveMu.Unlock()
+
+	ts.mu.Lock()
+	t, ok := ts.all[id]
+	var c data.Tournament
+	if ok {
+		c = cloneTournament(t)
+	}
+	ts.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if err := ts.repo.Save(c); err != nil {
+		log.Printf("failed to save tournament %s: %v", id, err)
+	}
+}
+
+// cloneTournament copies t so it can be used without ts.mu held.
+func cloneTournament(t *data.Tournament) data.Tournament {
+	c := *t
+	c.Players = slices.Clone(t.Players)
+	c.Matches = slices.Clone(t.Matches)
+	return c
+}
+
+// reserved reports whether id is, or could become, a tournament match room.
+func (ts *tournaments) reserved(id string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	for tid := range ts.all {
+		if n, ok := strings.CutPrefix(id, tid+"-"); ok {
+			if match, err := strconv.Atoi(n); err == nil && strconv.Itoa(match) == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// create starts registration for a new tournament organised by the key with
+// the given fingerprint. If players are named the bracket is drawn straight
+// away in that seed order.
+func (ts *tournaments) create(id, format, organiser string, players []string) error {
+	switch {
+	case len(id) > maxTournamentIDLen || !roomIDPattern.MatchString(id):
+		return fmt.Errorf("tournament ids are 1 to %d letters, digits, '-' and '_'", maxTournamentIDLen)
+	case slices.Contains(commands, id):
+		return fmt.Errorf("%q is a command and can't be a tournament id", id)
+	case format != data.SingleElimination && format != data.DoubleElimination:
+		return fmt.Errorf("unknown format %q — use %s or %s", format, data.SingleElimination, data.DoubleElimination)
+	case len(players) > maxTournamentPlayers:
+		return fmt.Errorf("a tournament takes at most %d players", maxTournamentPlayers)
+	}
+
+	t := &data.Tournament{ID: id, Format: format, Status: data.TournamentRegistering, Organiser: organiser}
+	for _, name := range players {
+		if err := addEntrant(t, name, ""); err != nil {
+			return err
+		}
+	}
+
+	ts.mu.Lock()
+	if _, ok := ts.all[id]; ok {
+		ts.mu.Unlock()
+		return errTournamentExists
+	}
+	if ts.repo != nil {
+		if _, err := ts.repo.Find(id); err == nil {
+			ts.mu.Unlock()
+			return errTournamentExists
+		}
+	}
+	ts.all[id] = t
+	if len(players) > 0 {
+		if err := drawLocked(t, false); err != nil {
+			delete(ts.all, id)
+			ts.mu.Unlock()
+			return err
+		}
+	}
+	ts.mu.Unlock()
+	ts.save(id)
+
+	log.Printf("tournament %s created (%s elimination, %d players)", id, format, len(players))
+	ts.openRooms()
+	return nil
+}
+
+// register enters a player into a tournament that is still registering.
+func (ts *tournaments) register(id, name, fingerprint string) error {
+	ts.mu.Lock()
+	t, ok := ts.all[id]
+	var err error
+	switch {
+	case !ok:
+		err = errNoTournament
+	case t.Status != data.TournamentRegistering:
+		err = errTournamentStarted
+	case len(t.Players) >= maxTournamentPlayers:
+		err = fmt.Errorf("the tournament is full (%d players)", maxTournamentPlayers)
+	default:
+		err = addEntrant(t, name, fingerprint)
+	}
+	ts.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	ts.save(id)
+	return nil
+}
+
+// addEntrant adds a player to t, refusing duplicate and reserved names.
+func addEntrant(t *data.Tournament, name, fingerprint string) error {
+	if name == "" || name == byeName {
+		return fmt.Errorf("%q can't be used as a player name", name)
+	}
+	for _, p := range t.Players {
+		if p.Name == name {
+			return fmt.Errorf("%s is already entered", name)
+		}
+	}
+	t.Players = append(t.Players, data.TournamentPlayer{Seed: len(t.Players) + 1, Name: name, Fingerprint: fingerprint})
+	return nil
+}
+
+// start closes registration and draws the bracket.
+func (ts *tournaments) start(id, fingerprint string) error {
+	ts.mu.Lock()
+	t, ok := ts.all[id]
+	switch {
+	case !ok:
+		ts.mu.Unlock()
+		return errNoTournament
+	case !ts.organises(t, fingerprint):
+		ts.mu.Unlock()
+		return errNotOrganiser
+	case t.Status != data.TournamentRegistering:
+		ts.mu.Unlock()
+		return errTournamentStarted
+	}
+	if err := drawLocked(t, true); err != nil {
+		ts.mu.Unlock()
+		return err
+	}
+	n := len(t.Players)
+	ts.mu.Unlock()
+	ts.save(id)
+
+	log.Printf("tournament %s started with %d players", id, n)
+	ts.openRooms()
+	return nil
+}
+
+// organises reports whether the key with the given fingerprint runs t: its
+// organiser or any server admin.
+func (ts *tournaments) organises(t *data.Tournament, fingerprint string) bool {
+	return fingerprint != "" && (t.Organiser == fingerprint || ts.srv.access.IsAdmin(fingerprint))
+}
+
+// drawLocked draws t's bracket and starts it. Players who registered
+// themselves are seeded at random. Caller must hold ts.mu.
+func drawLocked(t *data.Tournament, shuffle bool) error {
+	if len(t.Players) < 2 {
+		return errTournamentNotReady
+	}
+	if shuffle {
+		rand.Shuffle(len(t.Players), func(i, j int) { t.Players[i], t.Players[j] = t.Players[j], t.Players[i] })
+		for i := range t.Players {
+			t.Players[i].Seed = i + 1
+		}
+	}
+
+	names := make([]string, len(t.Players))
+	for i, p := range t.Players {
+		names[i] = p.Name
+	}
+	t.Matches = newBracket(t.Format, names)
+	t.Status = data.TournamentRunning
+	return nil
+}
+
+// award lets the organiser decide a match by hand, for a no-show or a match
+// played elsewhere.
+func (ts *tournaments) award(id, fingerprint string, match int, winner string) error {
+	ts.mu.Lock()
+	t, ok := ts.all[id]
+	switch {
+	case !ok:
+		ts.mu.Unlock()
+		return errNoTournament
+	case !ts.organises(t, fingerprint):
+		ts.mu.Unlock()
+		return errNotOrganiser
+	case match < 1 || match > len(t.Matches) || !playable(t.Matches[match-1]):
+		ts.mu.Unlock()
+		return fmt.Errorf("match %d is not waiting to be played", match)
+	case !slices.Contains(t.Matches[match-1].Players[:], winner):
+		ts.mu.Unlock()
+		return fmt.Errorf("%s is not playing match %d", winner, match)
+	}
+	ts.mu.Unlock()
+
+	ts.decide(id, match, winner)
+	return nil
+}
+
+// decide records the winner of a match, moves the bracket on and tells the
+// rooms involved.
+func (ts *tournaments) decide(id string, match int, winner string) {
+	ts.mu.Lock()
+	t, ok := ts.all[id]
+	if !ok || match < 1 || match > len(t.Matches) || !playable(t.Matches[match-1]) {
+		ts.mu.Unlock()
+		return
+	}
+	champion := decideMatch(t.Matches, match, winner)
+	if champion != "" {
+		t.Status = data.TournamentFinished
+		t.Champion = champion
+	}
+
+	// Tell the winner and loser where they play next.
+	m := t.Matches[match-1]
+	var notes []string
+	if champion != "" {
+		notes = append(notes, fmt.Sprintf("%s wins tournament %s! 🏆", champion, id))
+	} else {
+		notes = append(notes, fmt.Sprintf("%s wins match %d", winner, match))
+		for _, name := range m.Players {
+			if next := nextMatch(t, name); next > 0 {
+				notes = append(notes, fmt.Sprintf("%s plays next in room %s", name, matchRoomID(id, next)))
+			} else if name != winner && !stillIn(t, name) {
+				notes = append(notes, fmt.Sprintf("%s is out of the tournament", name))
+			}
+		}
+	}
+	ts.mu.Unlock()
+	ts.save(id)
+
+	log.Printf("tournament %s: %s won match %d", id, winner, match)
+	if room := ts.srv.FindRoom(matchRoomID(id, match)); room != nil {
+		for _, note := range notes {
+			room.post(NoteMsg(note))
+		}
+		room.post(roomChangedMsg{})
+	}
+	ts.openRooms()
+}
+
+// nextMatch returns the match name is waiting to play in t, or 0.
+func nextMatch(t *data.Tournament, name string) int {
+	for _, m := range t.Matches {
+		if playable(m) && slices.Contains(m.Players[:], name) {
+			return m.ID
+		}
+	}
+	return 0
+}
+
+// stillIn reports whether name has a match ahead of them in t, decided or
+// not.
+func stillIn(t *data.Tournament, name string) bool {
+	for _, m := range t.Matches {
+		if m.Winner == "" && slices.Contains(m.Players[:], name) {
+			return true
+		}
+	}
+	return false
+}
+
+// openRooms makes sure every playable match has a room. Rooms close when idle,
+// so a match whose players are slow to turn up gets a fresh one.
+func (ts *tournaments) openRooms() {
+	type pending struct {
+		tournament string
+		match      int
+	}
+	var open []pending
+
+	ts.mu.Lock()
+	for _, t := range ts.all {
+		if t.Status != data.TournamentRunning {
+			continue
+		}
+		for _, m := range t.Matches {
+			if playable(m) {
+				open = append(open, pending{t.ID, m.ID})
+			}
+		}
+	}
+	ts.mu.Unlock()
+
+	for _, p := range open {
+		id := matchRoomID(p.tournament, p.match)
+		tm := &tournamentMatch{ts: ts, tournament: p.tournament, match: p.match}
+		if _, err := ts.srv.createMatchRoom(id, tm); err != nil && !errors.Is(err, ErrRoomExists) {
+			log.Printf("failed to open room %s: %v", id, err)
+		}
+	}
+}
+
+// snapshot returns a copy of the tournament with the given id, looking in the
+// database for ones that have finished.
+func (ts *tournaments) snapshot(id string) (data.Tournament, error) {
+	ts.mu.Lock()
+	t, ok := ts.all[id]
+	if ok {
+		c := cloneTournament(t)
+		ts.mu.Unlock()
+		return c, nil
+	}
+	ts.mu.Unlock()
+
+	if ts.repo == nil {
+		return data.Tournament{}, errNoTournament
+	}
+	found, err := ts.repo.Find(id)
+	if errors.Is(err, data.ErrTournamentNotFound) {
+		return found, errNoTournament
+	}
+	return found, err
+}
+
+// list returns the ids of the tournaments in progress, in order.
+func (ts *tournaments) list() []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ids := make([]string, 0, len(ts.all))
+	for id, t := range ts.all {
+		if t.Status != data.TournamentFinished {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// tournamentMatch ties a room to the tournament match played in it.
+type tournamentMatch struct {
+	ts         *tournaments
+	tournament string
+	match      int
+}
+
+// mayPlay reports whether a session may take a seat in the match room. The
+// first key to play under an entrant's name claims it for the rest of the
+// tournament.
+func (tm *tournamentMatch) mayPlay(name, fingerprint string) bool {
+	tm.ts.mu.Lock()
+	t, ok := tm.ts.all[tm.tournament]
+	if !ok || !playable(t.Matches[tm.match-1]) || !slices.Contains(t.Matches[tm.match-1].Players[:], name) {
+		tm.ts.mu.Unlock()
+		return false
+	}
+	for i, p := range t.Players {
+		if p.Name != name {
+			continue
+		}
+		if p.Fingerprint != "" {
+			tm.ts.mu.Unlock()
+			return p.Fingerprint == fingerprint
+		}
+		t.Players[i].Fingerprint = fingerprint
+		tm.ts.mu.Unlock()
+		tm.ts.save(tm.tournament)
+		return true
+	}
+	tm.ts.mu.Unlock()
+	return false
+}
+
+// report records the winner of the match. Results after the first are
+// ignored, so the players may keep playing for fun.
+func (tm *tournamentMatch) report(winner string) {
+	tm.ts.decide(tm.tournament, tm.match, winner)
+}
+
+// bracketRow is one line of a laid out bracket: a round heading or a match.
+type bracketRow struct {
+	heading string
+	match   data.TournamentMatch
+}
+
+// bracketRows lays t's bracket out round by round. Decided rounds are left
+// out from the start until at most maxRows remain; hidden counts the matches
+// left out.
+func bracketRows(t data.Tournament, maxRows int) (rows []bracketRow, hidden int) {
+	type block struct {
+		heading string
+		matches []data.TournamentMatch
+	}
+	var blocks []block
+	for _, m := range t.Matches {
+		heading := roundName(t, m)
+		if len(blocks) == 0 || blocks[len(blocks)-1].heading != heading {
+			blocks = append(blocks, block{heading: heading})
+		}
+		b := &blocks[len(blocks)-1]
+		b.matches = append(b.matches, m)
+	}
+
+	total := 0
+	for _, b := range blocks {
+		total += 1 + len(b.matches)
+	}
+	for len(blocks) > 1 && total > maxRows && !slices.ContainsFunc(blocks[0].matches, func(m data.TournamentMatch) bool { return m.Winner == "" }) {
+		total -= 1 + len(blocks[0].matches)
+		hidden += len(blocks[0].matches)
+		blocks = blocks[1:]
+	}
+
+	for _, b := range blocks {
+		rows = append(rows, bracketRow{heading: b.heading})
+		for _, m := range b.matches {
+			rows = append(rows, bracketRow{match: m})
+		}
+	}
+	return rows, hidden
+}
+
+// roundName labels the round m is in, e.g. "Winners round 2" or "Final".
+func roundName(t data.Tournament, m data.TournamentMatch) string {
+	switch m.Bracket {
+	case data.BracketFinal:
+		return "Grand final"
+	case data.BracketLosers:
+		return fmt.Sprintf("Losers round %d", m.Round)
+	}
+	if t.Format == data.SingleElimination {
+		if m.Next == 0 {
+			return "Final"
+		}
+		return fmt.Sprintf("Round %d", m.Round)
+	}
+	return fmt.Sprintf("Winners round %d", m.Round)
+}
+
+// slotName is how a bracket slot is shown.
+func slotName(name string, n int) string {
+	if name == "" {
+		return "…"
+	}
+	return truncate(name, n)
+}
+
+// formatName is a tournament format as shown to players.
+func formatName(format string) string {
+	if format == data.DoubleElimination {
+		return "double elimination"
+	}
+	return "single elimination"
+}
+
+// bracketView shows the live bracket of this room's tournament.
+func (m *SharedMultiGame) bracketView() string {
+	s := m.styles
+	tm := m.player.room.match
+	label := s.sectionLbl.Width(standingsWidth)
+	title := s.title.Width(standingsWidth).Render("BRACKET")
+
+	t, err := tm.ts.snapshot(tm.tournament)
+	if err != nil {
+		return s.info.Width(standingsWidth + 2).Render(lipgloss.JoinVertical(lipgloss.Left,
+			title, "", label.Render("The bracket is unavailable.")))
+	}
+
+	lines := []string{
+		title,
+		label.Render(fmt.Sprintf("%s · %s", t.ID, formatName(t.Format))),
+		"",
+	}
+	rows, hidden := bracketRows(t, bracketPanelRows)
+	if hidden > 0 {
+		lines = append(lines, label.Render(fmt.Sprintf("%d earlier matches decided", hidden)))
+	}
+
+	winner := lipgloss.NewStyle().Foreground(colTitle).Bold(true)
+	loser := lipgloss.NewStyle().Foreground(colMuted)
+	for _, row := range rows {
+		if row.heading != "" {
+			lines = append(lines, s.sectionLbl.Width(standingsWidth).Foreground(colScore).Render(row.heading))
+			continue
+		}
+		mt := row.match
+		names := [2]string{}
+		for i, name := range mt.Players {
+			names[i] = fmt.Sprintf("%-13s", slotName(name, 13))
+			switch {
+			case mt.Winner == "":
+			case name == mt.Winner:
+				names[i] = winner.Render(names[i])
+			default:
+				names[i] = loser.Render(names[i])
+			}
+		}
+		marker := " "
+		if mt.ID == tm.match {
+			marker = "▶"
+		}
+		lines = append(lines, fmt.Sprintf("%s%3d %s v %s", marker, mt.ID, names[0], names[1]))
+	}
+	if t.Champion != "" {
+		lines = append(lines, "", winner.Render("Champion: "+t.Champion))
+	}
+
+	return s.info.Width(standingsWidth + 2).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// tournamentUsage lists the tournament commands.
+func tournamentUsage(reason string) string {
+	lines := []string{
+		"Tournaments",
+		"",
+		"Usage:",
+		"  ssh <name>@<host> -p <port> -t tournament                                  list tournaments",
+		"  ssh <name>@<host> -p <port> -t tournament <id>                             show the bracket",
+		"  ssh <name>@<host> -p <port> -t tournament new <id> single|double [names]   create one",
+		"  ssh <name>@<host> -p <port> -t tournament join <id>                        register",
+		"  ssh <name>@<host> -p <port> -t tournament start <id>                       draw the bracket",
+		"  ssh <name>@<host> -p <port> -t tournament award <id> <match> <name>        decide a match",
+		"",
+		"Notes:",
+		"  • Naming players when creating a tournament draws the bracket at once, seeded in",
+		"    that order. Otherwise players join until the organiser starts it.",
+		"  • Every match gets its own room, named <id>-<match>. Only its two players may",
+		"    sit down; the first key to play under a name keeps it for the tournament.",
+		"  • Only the organiser, whoever created the tournament, or a server admin can start",
+		"    it or award a match, for example when a player does not turn up.",
+		"  • On servers with admins configured, only admins can create tournaments.",
+		"",
+	}
+	if reason != "" {
+		lines = append(lines, "Error: "+reason, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tournamentSession runs a tournament command and writes its outcome to s.
+func tournamentSession(srv *Server, s ssh.Session, args []string) {
+	out, err := tournamentCommand(srv, s, args)
+	if err != nil {
+		_, _ = s.Write([]byte(tournamentUsage(err.Error())))
+		_ = s.Exit(1)
+		return
+	}
+	_, _ = s.Write([]byte(out))
+}
+
+func tournamentCommand(srv *Server, s ssh.Session, args []string) (string, error) {
+	ts := srv.tournaments
+	if len(args) == 0 {
+		ids := ts.list()
+		if len(ids) == 0 {
+			return tournamentUsage("") + "No tournaments are running.\n", nil
+		}
+		return tournamentUsage("") + "Running: " + strings.Join(ids, ", ") + "\n", nil
+	}
+
+	fingerprint := ""
+	if s.PublicKey() != nil {
+		fingerprint = PublicKey{key: s.PublicKey()}.Fingerprint()
+	}
+	needKey := func() error {
+		if fingerprint == "" {
+			return errors.New("no public key — re-run with: ssh -i <key> ...")
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "new":
+		if len(args) < 3 {
+			return "", errors.New("new needs an id and a format")
+		}
+		if err := needKey(); err != nil {
+			return "", err
+		}
+		if srv.access.HasAdmins() && !srv.access.IsAdmin(fingerprint) {
+			return "", errNotAdmin
+		}
+		if err := ts.create(args[1], args[2], fingerprint, args[3:]); err != nil {
+			return "", err
+		}
+		if len(args) > 3 {
+			return showBracket(ts, args[1], s.User())
+		}
+		return fmt.Sprintf("Tournament %s is open. Players register with: tournament join %s\n", args[1], args[1]), nil
+
+	case "join":
+		if len(args) != 2 {
+			return "", errors.New("join needs a tournament id")
+		}
+		if err := needKey(); err != nil {
+			return "", err
+		}
+		if err := ts.register(args[1], s.User(), fingerprint); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("You are entered in %s as %s.\n", args[1], s.User()), nil
+
+	case "start":
+		if len(args) != 2 {
+			return "", errors.New("start needs a tournament id")
+		}
+		if err := ts.start(args[1], fingerprint); err != nil {
+			return "", err
+		}
+		return showBracket(ts, args[1], s.User())
+
+	case "award":
+		if len(args) != 4 {
+			return "", errors.New("award needs a tournament id, a match number and the winner")
+		}
+		match, err := strconv.Atoi(args[2])
+		if err != nil {
+			return "", fmt.Errorf("%q is not a match number", args[2])
+		}
+		if err := ts.award(args[1], fingerprint, match, args[3]); err != nil {
+			return "", err
+		}
+		return showBracket(ts, args[1], s.User())
+
+	default:
+		if len(args) != 1 {
+			return "", fmt.Errorf("unknown tournament command %q", args[0])
+		}
+		return showBracket(ts, args[0], s.User())
+	}
+}
+
+// showBracket renders a tournament's bracket as plain text, pointing user at
+// their next match.
+func showBracket(ts *tournaments, id, user string) (string, error) {
+	t, err := ts.snapshot(id)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Tournament %s · %s · %s\n\n", t.ID, formatName(t.Format), t.Status)
+
+	if t.Status == data.TournamentRegistering {
+		fmt.Fprintf(&sb, "%d registered:\n", len(t.Players))
+		for _, p := range t.Players {
+			fmt.Fprintf(&sb, "  %s\n", p.Name)
+		}
+		return sb.String(), nil
+	}
+
+	rows, _ := bracketRows(t, len(t.Matches)*2)
+	for _, row := range rows {
+		if row.heading != "" {
+			fmt.Fprintf(&sb, "%s\n", row.heading)
+			continue
+		}
+		m := row.match
+		status := ""
+		switch {
+		case m.Winner != "":
+			status = "→ " + m.Winner
+		case playable(m):
+			status = "room " + matchRoomID(t.ID, m.ID)
+		}
+		line := fmt.Sprintf("  %3d  %-16s v %-16s %s", m.ID, slotName(m.Players[0], 16), slotName(m.Players[1], 16), status)
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	sb.WriteString("\n")
+
+	switch next := nextMatch(&t, user); {
+	case t.Champion != "":
+		fmt.Fprintf(&sb, "Champion: %s\n", t.Champion)
+	case next > 0:
+		fmt.Fprintf(&sb, "Your next match: ssh %s@<host> -t %s\n", user, matchRoomID(t.ID, next))
+	}
+	return sb.String(), nil
+}
+
+// reportMatch hands the result of the round that just ended to the
+// tournament. A draw settles nothing, so the players go again. Only called
+// from listen(), before endRound frees the seats of players who left.
+func (r *Room) reportMatch() {
+	winner := r.game.Winner()
+
+	r.mu.RLock()
+	name := ""
+	if winner >= 0 && winner < len(r.seats) {
+		name = r.seats[winner].name
+	}
+	r.mu.RUnlock()
+
+	if name == "" {
+		r.sendNote("A draw doesn't count — ready up to replay the match")
+		return
+	}
+	go r.match.report(name)
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HilthonTT/gosnake/internal/data"
+)
+
+func TestTournamentSave(t *testing.T) {
+	db, err := data.NewDB(filepath.Join(t.TempDir(), "gosnake.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	srv, err := NewServer(filepath.Join(t.TempDir(), "host_key"), "127.0.0.1", 0, db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.tournaments.stop()
+	defer func() {
+		for _, room := range srv.roomList() {
+			room.Close()
+		}
+	}()
+
+	if err := srv.tournaments.create("cup", data.SingleElimination, "", seededPlayers(2)); err != nil {
+		t.Fatal(err)
+	}
+	srv.tournaments.decide("cup", 1, "p2")
+
+	saved, err := data.NewTournamentRepository(db).Find("cup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != data.TournamentFinished || saved.Champion != "p2" || saved.Matches[0].Winner != "p2" {
+		t.Errorf("saved tournament = %s, champion %q, match 1 won by %q; want finished, won by p2",
+			saved.Status, saved.Champion, saved.Matches[0].Winner)
+	}
+}